@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -313,8 +314,12 @@ func (h *MockHandler) SelfGet(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func isMultipartRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data")
+}
+
 func (h *MockHandler) SendFile(w http.ResponseWriter, r *http.Request) {
-	if r.Method == http.MethodPost {
+	if isMultipartRequest(r) {
 		err := r.ParseMultipartForm(10 << 20) // 10MB max
 		if err != nil && err != io.EOF {
 			h.logger.WithFields(logrus.Fields{
@@ -336,8 +341,8 @@ func (h *MockHandler) SendFile(w http.ResponseWriter, r *http.Request) {
 			h.sendErrorResponse(w, "File cannot be empty")
 			return
 		}
-	} else if r.Method == http.MethodGet {
-		// GET request must have fileId parameter
+	} else if r.Method == http.MethodGet || r.Method == http.MethodPost {
+		// request without file must have fileId parameter
 		if r.FormValue("fileId") == "" {
 			h.sendErrorResponse(w, "Missing required parameter 'fileId'")
 			return
@@ -361,7 +366,7 @@ func (h *MockHandler) SendFile(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *MockHandler) SendVoice(w http.ResponseWriter, r *http.Request) {
-	if r.Method == http.MethodPost {
+	if isMultipartRequest(r) {
 		err := r.ParseMultipartForm(10 << 20) // 10MB max
 		if err != nil && err != io.EOF {
 			h.logger.WithFields(logrus.Fields{
@@ -383,8 +388,8 @@ func (h *MockHandler) SendVoice(w http.ResponseWriter, r *http.Request) {
 			h.sendErrorResponse(w, "File cannot be empty")
 			return
 		}
-	} else if r.Method == http.MethodGet {
-		// GET request must have fileId parameter
+	} else if r.Method == http.MethodGet || r.Method == http.MethodPost {
+		// request without file must have fileId parameter
 		if r.FormValue("fileId") == "" {
 			h.sendErrorResponse(w, "Missing required parameter 'fileId'")
 			return
@@ -451,6 +456,6 @@ func NewApiMockClient(t *testing.T) Client {
 		baseURL: testServer.URL,
 		token:   "test_token",
 		client:  http.DefaultClient,
-		logger:  &logrus.Logger{},
+		logger:  NewLogrusLogger(&logrus.Logger{}),
 	}
 }
@@ -8,7 +8,9 @@ Crafted with love in @mail for your awesome bots.
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 )
@@ -23,114 +25,223 @@ const (
 type Bot struct {
 	client  *Client
 	updater *Updater
-	logger  *logrus.Logger
+	logger  Logger
 	Info    *BotInfo
+
+	mu         sync.Mutex
+	dispatcher *Dispatcher
 }
 
 // AutosubscribeToThreads toggles thread auto-subscription behaviour for the specified chat.
 // enable – turn the feature on/off.
 // withExisting – if true, the bot will also subscribe to already existing threads.
 func (b *Bot) AutosubscribeToThreads(chatID string, enable, withExisting bool) error {
-	return b.client.AutosubscribeToThreads(chatID, enable, withExisting)
+	return b.AutosubscribeToThreadsWithContext(context.Background(), chatID, enable, withExisting)
+}
+
+// AutosubscribeToThreadsWithContext is AutosubscribeToThreads with a context
+func (b *Bot) AutosubscribeToThreadsWithContext(ctx context.Context, chatID string, enable, withExisting bool) error {
+	return b.client.AutosubscribeToThreadsWithContext(ctx, chatID, enable, withExisting)
 }
 
 // AddThread adds a new thread to the specified chat and returns the thread ID.
 func (b *Bot) AddThread(chatID, msgID string) (*Thread, error) {
-	return b.client.AddThread(chatID, msgID)
+	return b.AddThreadWithContext(context.Background(), chatID, msgID)
+}
+
+// AddThreadWithContext is AddThread with a context
+func (b *Bot) AddThreadWithContext(ctx context.Context, chatID, msgID string) (*Thread, error) {
+	return b.client.AddThreadWithContext(ctx, chatID, msgID)
 }
 
 // GetThreadSubscribers gets the subscribers list for a thread.
 // Either cursor or pageSize must be provided.
 func (b *Bot) GetThreadSubscribers(threadID string, cursor string, pageSize int) (*ThreadSubscribers, error) {
-	return b.client.GetThreadSubscribers(threadID, cursor, pageSize)
+	return b.GetThreadSubscribersWithContext(context.Background(), threadID, cursor, pageSize)
+}
+
+// GetThreadSubscribersWithContext is GetThreadSubscribers with a context
+func (b *Bot) GetThreadSubscribersWithContext(ctx context.Context, threadID string, cursor string, pageSize int) (*ThreadSubscribers, error) {
+	return b.client.GetThreadSubscribersWithContext(ctx, threadID, cursor, pageSize)
 }
 
 // GetInfo returns information about bot:
 // id, name, about, avatar
 func (b *Bot) GetInfo() (*BotInfo, error) {
-	return b.client.GetInfo()
+	return b.GetInfoWithContext(context.Background())
+}
+
+// GetInfoWithContext is GetInfo with a context
+func (b *Bot) GetInfoWithContext(ctx context.Context) (*BotInfo, error) {
+	return b.client.GetInfoWithContext(ctx)
 }
 
 // GetChatInfo returns information about chat:
 // id, type, title, public, group, inviteLink, admins
 func (b *Bot) GetChatInfo(chatID string) (*Chat, error) {
-	return b.client.GetChatInfo(chatID)
+	return b.GetChatInfoWithContext(context.Background(), chatID)
+}
+
+// GetChatInfoWithContext is GetChatInfo with a context
+func (b *Bot) GetChatInfoWithContext(ctx context.Context, chatID string) (*Chat, error) {
+	return b.client.GetChatInfoWithContext(ctx, chatID)
 }
 
 // SendChatActions sends an actions like "typing, looking"
 func (b *Bot) SendChatActions(chatID string, actions ...ChatAction) error {
-	return b.client.SendChatActions(chatID, actions...)
+	return b.SendChatActionsWithContext(context.Background(), chatID, actions...)
+}
+
+// SendChatActionsWithContext is SendChatActions with a context
+func (b *Bot) SendChatActionsWithContext(ctx context.Context, chatID string, actions ...ChatAction) error {
+	return b.client.SendChatActionsWithContext(ctx, chatID, actions...)
 }
 
 // GetChatAdmins returns chat admins list with fields:
 // userID, creator flag
 func (b *Bot) GetChatAdmins(chatID string) ([]ChatMember, error) {
-	return b.client.GetChatAdmins(chatID)
+	return b.GetChatAdminsWithContext(context.Background(), chatID)
+}
+
+// GetChatAdminsWithContext is GetChatAdmins with a context
+func (b *Bot) GetChatAdminsWithContext(ctx context.Context, chatID string) ([]ChatMember, error) {
+	return b.client.GetChatAdminsWithContext(ctx, chatID)
 }
 
 // GetChatMembers returns chat members list with fields:
 // userID, creator flag, admin flag
 func (b *Bot) GetChatMembers(chatID string) ([]ChatMember, error) {
-	return b.client.GetChatMembers(chatID)
+	return b.GetChatMembersWithContext(context.Background(), chatID)
+}
+
+// GetChatMembersWithContext is GetChatMembers with a context
+func (b *Bot) GetChatMembersWithContext(ctx context.Context, chatID string) ([]ChatMember, error) {
+	return b.client.GetChatMembersWithContext(ctx, chatID)
 }
 
 // GetChatBlockedUsers returns chat blocked users list:
 // userID
 func (b *Bot) GetChatBlockedUsers(chatID string) ([]User, error) {
-	return b.client.GetChatBlockedUsers(chatID)
+	return b.GetChatBlockedUsersWithContext(context.Background(), chatID)
+}
+
+// GetChatBlockedUsersWithContext is GetChatBlockedUsers with a context
+func (b *Bot) GetChatBlockedUsersWithContext(ctx context.Context, chatID string) ([]User, error) {
+	return b.client.GetChatBlockedUsersWithContext(ctx, chatID)
 }
 
 // GetChatPendingUsers returns chat join pending users list:
 // userID
 func (b *Bot) GetChatPendingUsers(chatID string) ([]User, error) {
-	return b.client.GetChatPendingUsers(chatID)
+	return b.GetChatPendingUsersWithContext(context.Background(), chatID)
+}
+
+// GetChatPendingUsersWithContext is GetChatPendingUsers with a context
+func (b *Bot) GetChatPendingUsersWithContext(ctx context.Context, chatID string) ([]User, error) {
+	return b.client.GetChatPendingUsersWithContext(ctx, chatID)
 }
 
 // BlockChatUser blocks user and removes him from chat.
 // If deleteLastMessages is true, the messages written recently will be deleted
 func (b *Bot) BlockChatUser(chatID, userID string, deleteLastMessages bool) error {
-	return b.client.BlockChatUser(chatID, userID, deleteLastMessages)
+	return b.BlockChatUserWithContext(context.Background(), chatID, userID, deleteLastMessages)
+}
+
+// BlockChatUserWithContext is BlockChatUser with a context
+func (b *Bot) BlockChatUserWithContext(ctx context.Context, chatID, userID string, deleteLastMessages bool) error {
+	return b.client.BlockChatUserWithContext(ctx, chatID, userID, deleteLastMessages)
 }
 
 // UnblockChatUser unblocks user in chat
 func (b *Bot) UnblockChatUser(chatID, userID string) error {
-	return b.client.UnblockChatUser(chatID, userID)
+	return b.UnblockChatUserWithContext(context.Background(), chatID, userID)
+}
+
+// UnblockChatUserWithContext is UnblockChatUser with a context
+func (b *Bot) UnblockChatUserWithContext(ctx context.Context, chatID, userID string) error {
+	return b.client.UnblockChatUserWithContext(ctx, chatID, userID)
 }
 
 // DeleteChatMembers removes multiple members from chat
 func (b *Bot) DeleteChatMembers(chatID string, members []string) error {
-	return b.client.DeleteChatMembers(chatID, members)
+	return b.DeleteChatMembersWithContext(context.Background(), chatID, members)
+}
+
+// DeleteChatMembersWithContext is DeleteChatMembers with a context
+func (b *Bot) DeleteChatMembersWithContext(ctx context.Context, chatID string, members []string) error {
+	return b.client.DeleteChatMembersWithContext(ctx, chatID, members)
 }
 
 // AddChatMembers adds multiple members to chat
 func (b *Bot) AddChatMembers(chatID string, members []string) error {
-	return b.client.AddChatMembers(chatID, members)
+	return b.AddChatMembersWithContext(context.Background(), chatID, members)
+}
+
+// AddChatMembersWithContext is AddChatMembers with a context
+func (b *Bot) AddChatMembersWithContext(ctx context.Context, chatID string, members []string) error {
+	return b.client.AddChatMembersWithContext(ctx, chatID, members)
 }
 
 // ResolveChatJoinRequests resolves pending join requests for specified user or all pending users
 func (b *Bot) ResolveChatJoinRequests(chatID, userID string, accept, everyone bool) error {
-	return b.client.ResolveChatPending(chatID, userID, accept, everyone)
+	return b.ResolveChatJoinRequestsWithContext(context.Background(), chatID, userID, accept, everyone)
+}
+
+// ResolveChatJoinRequestsWithContext is ResolveChatJoinRequests with a context
+func (b *Bot) ResolveChatJoinRequestsWithContext(ctx context.Context, chatID, userID string, accept, everyone bool) error {
+	return b.client.ResolveChatPendingWithContext(ctx, chatID, userID, accept, everyone)
 }
 
 // SetChatTitle changes chat title
 func (b *Bot) SetChatTitle(chatID, title string) error {
-	return b.client.SetChatTitle(chatID, title)
+	return b.SetChatTitleWithContext(context.Background(), chatID, title)
+}
+
+// SetChatTitleWithContext is SetChatTitle with a context
+func (b *Bot) SetChatTitleWithContext(ctx context.Context, chatID, title string) error {
+	return b.client.SetChatTitleWithContext(ctx, chatID, title)
 }
 
 // SetChatAbout changes chat about
 func (b *Bot) SetChatAbout(chatID, about string) error {
-	return b.client.SetChatAbout(chatID, about)
+	return b.SetChatAboutWithContext(context.Background(), chatID, about)
+}
+
+// SetChatAboutWithContext is SetChatAbout with a context
+func (b *Bot) SetChatAboutWithContext(ctx context.Context, chatID, about string) error {
+	return b.client.SetChatAboutWithContext(ctx, chatID, about)
 }
 
 // SetChatRules changes chat rules
 func (b *Bot) SetChatRules(chatID, rules string) error {
-	return b.client.SetChatRules(chatID, rules)
+	return b.SetChatRulesWithContext(context.Background(), chatID, rules)
+}
+
+// SetChatRulesWithContext is SetChatRules with a context
+func (b *Bot) SetChatRulesWithContext(ctx context.Context, chatID, rules string) error {
+	return b.client.SetChatRulesWithContext(ctx, chatID, rules)
 }
 
 // GetFileInfo returns information about file:
 // id, type, size, filename, url
 func (b *Bot) GetFileInfo(fileID string) (*File, error) {
-	return b.client.GetFileInfo(fileID)
+	return b.GetFileInfoWithContext(context.Background(), fileID)
+}
+
+// GetFileInfoWithContext is GetFileInfo with a context
+func (b *Bot) GetFileInfoWithContext(ctx context.Context, fileID string) (*File, error) {
+	return b.client.GetFileInfoWithContext(ctx, fileID)
+}
+
+// DownloadFile writes the content of the file to w and returns information about the file.
+// Interrupted downloads are continued from the last received byte.
+func (b *Bot) DownloadFile(ctx context.Context, fileID string, w io.Writer, opts ...DownloadOption) (*File, error) {
+	return b.client.DownloadFile(ctx, fileID, w, opts...)
+}
+
+// OpenFile opens the file for reading, the reader must be closed by the caller
+func (b *Bot) OpenFile(ctx context.Context, fileID string, opts ...DownloadOption) (io.ReadCloser, *File, error) {
+	return b.client.OpenFile(ctx, fileID, opts...)
 }
 
 // NewMessage returns new message
@@ -257,13 +368,35 @@ func (b *Bot) NewChat(id string) *Chat {
 // SendMessage sends a message, passed as an argument.
 // This method fills the argument with ID of sent message and returns an error if any.
 func (b *Bot) SendMessage(message *Message) error {
+	return b.SendMessageWithContext(context.Background(), message)
+}
+
+// SendMessageWithContext is SendMessage with a context
+func (b *Bot) SendMessageWithContext(ctx context.Context, message *Message) error {
 	message.client = b.client
-	return message.Send()
+	return message.SendWithContext(ctx)
+}
+
+// SendSplitMessage sends a text message split into several messages if the text is too long.
+// It returns IDs of the sent messages, see Message.SendSplit.
+func (b *Bot) SendSplitMessage(message *Message) ([]string, error) {
+	return b.SendSplitMessageWithContext(context.Background(), message)
+}
+
+// SendSplitMessageWithContext is SendSplitMessage with a context
+func (b *Bot) SendSplitMessageWithContext(ctx context.Context, message *Message) ([]string, error) {
+	message.client = b.client
+	return message.SendSplitWithContext(ctx)
 }
 
 // EditMessage edit a message passed as an argument.
 func (b *Bot) EditMessage(message *Message) error {
-	return b.client.EditMessage(message)
+	return b.EditMessageWithContext(context.Background(), message)
+}
+
+// EditMessageWithContext is EditMessage with a context
+func (b *Bot) EditMessageWithContext(ctx context.Context, message *Message) error {
+	return b.client.EditMessageWithContext(ctx, message)
 }
 
 // GetUpdatesChannel returns a channel, which will be filled with events.
@@ -277,19 +410,89 @@ func (b *Bot) GetUpdatesChannel(ctx context.Context) <-chan Event {
 	return updates
 }
 
-// NewBot returns new bot object.
-// All communications with bot API must go through Bot struct.
-// In general you don't need to configure this bot, therefore all options are optional arguments.
-func NewBot(token string, opts ...BotOption) (*Bot, error) {
+// Dispatch receives events and handles them concurrently with a Dispatcher.
+// Handlers get the bot, the event and the logger with fields of the event from the context,
+// see BotFromContext, EventFromContext and LoggerFromContext.
+// It blocks until the context is done and all received events are handled.
+// Handler contexts keep the values of ctx but are not cancelled with it, so the received events
+// can still call API, they are cancelled when Shutdown reaches its deadline.
+func (b *Bot) Dispatch(ctx context.Context, handler Handler, config DispatcherConfig) {
+	if config.Logger == nil {
+		config.Logger = b.logger
+	}
+
+	dispatcher := NewDispatcher(HandlerFunc(func(ctx context.Context, event Event) error {
+		return handler.Handle(b.eventContext(ctx, event), event)
+	}), config)
+
+	b.mu.Lock()
+	b.dispatcher = dispatcher
+	b.mu.Unlock()
+	b.updater.dispatched = true
+
+	dispatcher.Run(b.GetUpdatesChannel(ctx))
+}
+
+// Shutdown stops receiving events, delivers the already received ones
+// and waits for handlers started by Dispatch until the context is done.
+// The report contains events which were not handled.
+func (b *Bot) Shutdown(ctx context.Context) (*ShutdownReport, error) {
+	report, err := b.updater.Shutdown(ctx)
+
+	b.mu.Lock()
+	dispatcher := b.dispatcher
+	b.mu.Unlock()
+
+	if dispatcher != nil {
+		handlers, dispatcherErr := dispatcher.Shutdown(ctx)
+		report.Unhandled = handlers.Unhandled
+		report.Running = handlers.Running
+		if err == nil {
+			err = dispatcherErr
+		}
+	}
+
+	return report, err
+}
+
+// newDefaultLogger returns logrus logger used when BotLogger option is not set
+func newDefaultLogger(debug bool) Logger {
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.TextFormatter{
 		FullTimestamp:   true,
 		TimestampFormat: "2006-01-02 15:04:05",
 	})
+	if debug {
+		logger.SetLevel(logrus.DebugLevel)
+	}
+	return NewLogrusLogger(logger)
+}
 
+// NewBot returns new bot object.
+// All communications with bot API must go through Bot struct.
+// In general you don't need to configure this bot, therefore all options are optional arguments.
+func NewBot(token string, opts ...BotOption) (*Bot, error) {
+	return NewBotWithContext(context.Background(), token, opts...)
+}
+
+// NewBotWithContext is NewBot with a context, which limits the initial request for bot info.
+func NewBotWithContext(ctx context.Context, token string, opts ...BotOption) (*Bot, error) {
 	apiURL := defaultAPIURL
 	debug := defaultDebug
 	client := *http.DefaultClient
+	retryPolicy := RetryPolicy{}
+	maxUploadSize := int64(0)
+	maxDownloadSize := int64(0)
+	var progress UploadProgressFunc
+	var limiter RateLimiter
+	var interceptors []Interceptor
+	var metrics Metrics
+	var tracer Tracer
+	var logger Logger
+	var postPaths map[string]bool
+	maxURLLength := 0
+	maxTextLength := 0
+	var offsetStore OffsetStore
 	for _, option := range opts {
 		switch option.Type() {
 		case "api_url":
@@ -298,19 +501,68 @@ func NewBot(token string, opts ...BotOption) (*Bot, error) {
 			debug = option.Value().(bool)
 		case "http_client":
 			client = option.Value().(http.Client)
+		case "retry_policy":
+			retryPolicy = option.Value().(RetryPolicy)
+		case "max_upload_size":
+			maxUploadSize = option.Value().(int64)
+		case "max_download_size":
+			maxDownloadSize = option.Value().(int64)
+		case "upload_progress":
+			progress = option.Value().(UploadProgressFunc)
+		case "rate_limiter":
+			limiter = option.Value().(RateLimiter)
+		case "interceptors":
+			interceptors = append(interceptors, option.Value().([]Interceptor)...)
+		case "metrics":
+			metrics = option.Value().(Metrics)
+		case "tracer":
+			tracer = option.Value().(Tracer)
+		case "logger":
+			logger = option.Value().(Logger)
+		case "post_endpoints":
+			if postPaths == nil {
+				postPaths = make(map[string]bool)
+			}
+			for _, path := range option.Value().([]string) {
+				postPaths[path] = true
+			}
+		case "max_url_length":
+			maxURLLength = option.Value().(int)
+		case "max_text_length":
+			maxTextLength = option.Value().(int)
+		case "offset_store":
+			offsetStore = option.Value().(OffsetStore)
 		}
 	}
 
-	if debug {
-		logger.SetLevel(logrus.DebugLevel)
+	if logger == nil {
+		logger = newDefaultLogger(debug)
 	}
 
-	tgClient := NewCustomClient(&client, apiURL, token, logger)
-	updater := NewUpdater(tgClient, 0, logger)
+	tgClient := NewCustomClientWithLogger(&client, apiURL, token, logger)
+	tgClient.retryPolicy = retryPolicy
+	tgClient.maxUploadSize = maxUploadSize
+	tgClient.maxDownloadSize = maxDownloadSize
+	tgClient.progress = progress
+	tgClient.limiter = limiter
+	tgClient.interceptors = interceptors
+	tgClient.metrics = metrics
+	tgClient.tracer = tracer
+	tgClient.postPaths = postPaths
+	tgClient.maxURLLength = maxURLLength
+	tgClient.maxTextLength = maxTextLength
+	updater := NewUpdaterWithLogger(tgClient, 0, logger)
+	if offsetStore != nil {
+		var err error
+		updater, err = NewUpdaterFromStore(tgClient, 0, logger, offsetStore)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	info, err := tgClient.GetInfo()
+	info, err := tgClient.GetInfoWithContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get info about bot: %s", err)
+		return nil, fmt.Errorf("cannot get info about bot: %w", err)
 	}
 
 	return &Bot{
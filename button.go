@@ -1,5 +1,7 @@
 package botgolang
 
+import "context"
+
 //go:generate easyjson -all button.go
 
 // Button represents a button in inline keyboard
@@ -52,7 +54,8 @@ func NewCallbackButton(text string, callbackData string) Button {
 
 // ButtonResponse represents a data that is returned when a button is clicked
 type ButtonResponse struct {
-	client *Client
+	client   *Client
+	answered bool
 
 	// Id of the query
 	QueryID string `json:"queryId"`
@@ -73,5 +76,16 @@ type ButtonResponse struct {
 // Send method sends your response message.
 // Make sure you have QueryID in your ButtonResponse.
 func (cl *ButtonResponse) Send() error {
-	return cl.client.SendAnswerCallbackQuery(cl)
+	return cl.SendWithContext(context.Background())
+}
+
+// SendWithContext is Send with a context
+func (cl *ButtonResponse) SendWithContext(ctx context.Context) error {
+	return cl.client.SendAnswerCallbackQueryWithContext(ctx, cl)
+}
+
+// Answered reports whether the response was sent successfully.
+// Every callback query must be answered once, otherwise the user sees a spinner on the button.
+func (cl *ButtonResponse) Answered() bool {
+	return cl.answered
 }
@@ -1,119 +1,243 @@
 package botgolang
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
 type Client struct {
-	client  *http.Client
-	token   string
-	baseURL string
-	logger  *logrus.Logger
+	client          *http.Client
+	token           string
+	baseURL         string
+	logger          Logger
+	retryPolicy     RetryPolicy
+	maxUploadSize   int64
+	maxDownloadSize int64
+	progress        UploadProgressFunc
+	limiter         RateLimiter
+	interceptors    []Interceptor
+	metrics         Metrics
+	tracer          Tracer
+	postPaths       map[string]bool
+	maxURLLength    int
+	maxTextLength   int
 }
 
 func (c *Client) Do(path string, params url.Values, file UploadFile) ([]byte, error) {
 	return c.DoWithContext(context.Background(), path, params, file)
 }
 
+// DoWithContext makes a request to the API method by path.
+// The request passes through the client's interceptors,
+// failed requests are repeated according to the client's RetryPolicy.
 func (c *Client) DoWithContext(ctx context.Context, path string, params url.Values, file UploadFile) ([]byte, error) {
-	apiURL, err := url.Parse(c.baseURL + path)
-	params.Set("token", c.token)
+	call := newAPICall(path, params, file)
+	ctx, span := c.traceCall(ctx, call)
 
-	if err != nil {
-		return nil, fmt.Errorf("cannot parse url: %s", err)
-	}
+	start := time.Now()
+	result, err := c.invoker()(ctx, call)
+	c.observeCall(path, start, err)
+	endCall(span, call, err)
 
-	apiURL.RawQuery = params.Encode()
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL.String(), nil)
-	if err != nil || req == nil {
-		return nil, fmt.Errorf("cannot init http request: %s", err)
+	if result == nil {
+		return nil, err
 	}
+	return result.Body, err
+}
 
-	if file != nil {
-		buffer := &bytes.Buffer{}
-		multipartWriter := multipart.NewWriter(buffer)
+// doWithRetries makes a request and repeats it according to the client's RetryPolicy
+func (c *Client) doWithRetries(ctx context.Context, path string, params url.Values, file UploadFile) ([]byte, error) {
+	rewinder := newUploadRewinder(file)
 
-		fileWriter, err := multipartWriter.CreateFormFile("file", file.Name())
-		if err != nil {
-			return nil, fmt.Errorf("cannot create multipart writer: %s", err)
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, path, params); err != nil {
+				return nil, err
+			}
 		}
 
-		_, err = io.Copy(fileWriter, file)
-		if err != nil {
-			return nil, fmt.Errorf("cannot copy file into buffer: %s", err)
+		response, err := c.do(ctx, path, params, file)
+		if err == nil {
+			return response, nil
+		}
+
+		var apiErr *APIError
+		if c.limiter != nil && errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			c.limiter.Backoff(path, params, apiErr.RetryAfter)
+		}
+
+		delay, retry := c.retryPolicy.shouldRetry(attempt, path, err)
+		if !retry || ctx.Err() != nil {
+			return response, err
+		}
+
+		if rewinder == nil {
+			c.logger.Log(LogLevelDebug, "cannot retry request: upload file is not seekable", LogFields{
+				"path": path,
+			})
+			return response, err
+		}
+
+		c.logger.Log(LogLevelWarn, "request failed, retrying", LogFields{
+			"err":     err,
+			"path":    path,
+			"attempt": attempt,
+			"delay":   delay,
+		})
+		c.observeRetry(path)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return response, err
+		}
+
+		if err := rewinder.rewind(); err != nil {
+			return nil, fmt.Errorf("cannot rewind upload file: %w", err)
 		}
+	}
+}
+
+// do makes a single attempt of the request
+func (c *Client) do(ctx context.Context, path string, params url.Values, file UploadFile) ([]byte, error) {
+	apiURL, err := url.Parse(c.baseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse url: %w", err)
+	}
+
+	// params are shared with interceptors, so the token is added to a copy
+	query := cloneValues(params)
+	query.Set("token", c.token)
+	apiURL.RawQuery = query.Encode()
+
+	// long params are sent in the body, only the token stays in the query
+	method := http.MethodGet
+	var body io.Reader
+	if file == nil && c.usePost(path, apiURL) {
+		apiURL.RawQuery = url.Values{"token": {c.token}}.Encode()
+		method = http.MethodPost
+		body = strings.NewReader(params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL.String(), body)
+	if err != nil || req == nil {
+		return nil, fmt.Errorf("cannot init http request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
 
-		if err := multipartWriter.Close(); err != nil {
-			return nil, fmt.Errorf("cannot close multipartWriter: %s", err)
+	if file != nil {
+		upload, err := c.newMultipartUpload(ctx, file)
+		if err != nil {
+			return nil, err
 		}
+		// the transport may close the body after the response, the file must not be read after return
+		defer upload.finish()
 
-		req.Header.Set("Content-Type", multipartWriter.FormDataContentType())
-		req.Body = io.NopCloser(buffer)
+		req.Header.Set("Content-Type", upload.contentType)
+		req.Body = upload.body
+		req.ContentLength = upload.contentLength
 		req.Method = http.MethodPost
 	}
 
-	c.logger.WithFields(logrus.Fields{
-		"api_url": apiURL,
-	}).Debug("requesting api")
+	c.logger.Log(LogLevelDebug, "requesting api", LogFields{
+		"method":  req.Method,
+		"api_url": redactURL(apiURL),
+	})
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		c.logger.WithFields(logrus.Fields{
+		err = redactError(err)
+		c.logger.Log(LogLevelError, "request error", LogFields{
 			"err": err,
-		}).Error("request error")
-		return []byte{}, fmt.Errorf("cannot make request to bot api: %s", err)
+		})
+		if errors.Is(err, ErrFileTooLarge) {
+			return []byte{}, err
+		}
+		return []byte{}, &TransportError{Path: path, Err: err}
 	}
 
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
-			c.logger.WithFields(logrus.Fields{
+			c.logger.Log(LogLevelError, "cannot close body", LogFields{
 				"err": err,
-			}).Error("cannot close body")
+			})
 		}
 	}()
 
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		c.logger.WithFields(logrus.Fields{
+		c.logger.Log(LogLevelError, "cannot read body", LogFields{
 			"err": err,
-		}).Error("cannot read body")
-		return []byte{}, fmt.Errorf("cannot read body: %s", err)
+		})
+		return []byte{}, fmt.Errorf("cannot read body: %w", &TransportError{Path: path, Err: err})
 	}
 
-	if c.logger.IsLevelEnabled(logrus.DebugLevel) {
-		c.logger.WithFields(logrus.Fields{
+	if c.logger.Enabled(LogLevelDebug) {
+		c.logger.Log(LogLevelDebug, "got response from API", LogFields{
 			"response": responseBody,
-		}).Debug("got response from API")
+		})
+	}
+
+	requestID := params.Get("request-id")
+	if requestID == "" {
+		requestID = resp.Header.Get("X-Request-Id")
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("error status from API: %s", resp.Status)
+		// the body of error statuses may describe the error, it is ignored if it cannot be decoded
+		response := &Response{}
+		_ = json.Unmarshal(responseBody, response)
+
+		return nil, &APIError{
+			StatusCode:  resp.StatusCode,
+			Description: response.Description,
+			Path:        path,
+			RequestID:   requestID,
+			RetryAfter:  parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 	}
 
 	response := &Response{}
 
-	if err := json.Unmarshal(responseBody, response); err != nil {
-		return nil, fmt.Errorf("cannot unmarshal json: %s", err)
+	if err := unmarshalResponse(path, responseBody, response); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal json: %w", err)
 	}
 
 	if !response.OK {
-		return responseBody, fmt.Errorf("error status from API: %s", response.Description)
+		return responseBody, &APIError{
+			StatusCode:  resp.StatusCode,
+			Description: response.Description,
+			Path:        path,
+			RequestID:   requestID,
+		}
 	}
 
 	return responseBody, nil
 }
 
+// unmarshalResponse decodes the response of API method into v
+func unmarshalResponse(path string, data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return &DecodeError{Path: path, Err: err}
+	}
+	return nil
+}
+
 func (c *Client) AutosubscribeToThreads(chatID string, enable, withExisting bool) error {
+	return c.AutosubscribeToThreadsWithContext(context.Background(), chatID, enable, withExisting)
+}
+
+func (c *Client) AutosubscribeToThreadsWithContext(ctx context.Context, chatID string, enable, withExisting bool) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -124,7 +248,7 @@ func (c *Client) AutosubscribeToThreads(chatID string, enable, withExisting bool
 		"withExisting": {strconv.FormatBool(withExisting)},
 	}
 
-	if _, err := c.Do("/threads/autosubscribe", params, nil); err != nil {
+	if _, err := c.DoWithContext(ctx, "/threads/autosubscribe", params, nil); err != nil {
 		return fmt.Errorf("error while requesting threads autosubscribe: %w", err)
 	}
 
@@ -132,6 +256,10 @@ func (c *Client) AutosubscribeToThreads(chatID string, enable, withExisting bool
 }
 
 func (c *Client) AddThread(chatID, msgID string) (*Thread, error) {
+	return c.AddThreadWithContext(context.Background(), chatID, msgID)
+}
+
+func (c *Client) AddThreadWithContext(ctx context.Context, chatID, msgID string) (*Thread, error) {
 	if chatID == "" {
 		return nil, fmt.Errorf("chatID cannot be empty")
 	}
@@ -144,13 +272,13 @@ func (c *Client) AddThread(chatID, msgID string) (*Thread, error) {
 		"msgId":  {msgID},
 	}
 
-	response, err := c.Do("/threads/add", params, nil)
+	response, err := c.DoWithContext(ctx, "/threads/add", params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error while adding thread: %w", err)
 	}
 
 	thread := &Thread{}
-	if err := json.Unmarshal(response, thread); err != nil {
+	if err := unmarshalResponse("/threads/add", response, thread); err != nil {
 		return nil, fmt.Errorf("error while unmarshalling thread response: %w", err)
 	}
 
@@ -158,6 +286,10 @@ func (c *Client) AddThread(chatID, msgID string) (*Thread, error) {
 }
 
 func (c *Client) GetThreadSubscribers(threadID string, cursor string, pageSize int) (*ThreadSubscribers, error) {
+	return c.GetThreadSubscribersWithContext(context.Background(), threadID, cursor, pageSize)
+}
+
+func (c *Client) GetThreadSubscribersWithContext(ctx context.Context, threadID string, cursor string, pageSize int) (*ThreadSubscribers, error) {
 	if threadID == "" {
 		return nil, fmt.Errorf("threadID cannot be empty")
 	}
@@ -173,13 +305,13 @@ func (c *Client) GetThreadSubscribers(threadID string, cursor string, pageSize i
 		params.Set("pageSize", strconv.Itoa(pageSize))
 	}
 
-	response, err := c.Do("/threads/subscribers/get", params, nil)
+	response, err := c.DoWithContext(ctx, "/threads/subscribers/get", params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error while getting thread subscribers: %w", err)
 	}
 
 	threadSubscribers := &ThreadSubscribers{}
-	if err := json.Unmarshal(response, threadSubscribers); err != nil {
+	if err := unmarshalResponse("/threads/subscribers/get", response, threadSubscribers); err != nil {
 		return nil, fmt.Errorf("error while unmarshalling thread subscribers response: %w", err)
 	}
 
@@ -187,20 +319,28 @@ func (c *Client) GetThreadSubscribers(threadID string, cursor string, pageSize i
 }
 
 func (c *Client) GetInfo() (*BotInfo, error) {
-	response, err := c.Do("/self/get", url.Values{}, nil)
+	return c.GetInfoWithContext(context.Background())
+}
+
+func (c *Client) GetInfoWithContext(ctx context.Context) (*BotInfo, error) {
+	response, err := c.DoWithContext(ctx, "/self/get", url.Values{}, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error while receiving information: %s", err)
+		return nil, fmt.Errorf("error while receiving information: %w", err)
 	}
 
 	info := &BotInfo{}
-	if err := json.Unmarshal(response, info); err != nil {
-		return nil, fmt.Errorf("error while unmarshalling information: %s", err)
+	if err := unmarshalResponse("/self/get", response, info); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling information: %w", err)
 	}
 
 	return info, nil
 }
 
 func (c *Client) GetChatInfo(chatID string) (*Chat, error) {
+	return c.GetChatInfoWithContext(context.Background(), chatID)
+}
+
+func (c *Client) GetChatInfoWithContext(ctx context.Context, chatID string) (*Chat, error) {
 	if chatID == "" {
 		return nil, fmt.Errorf("chatID cannot be empty")
 	}
@@ -208,17 +348,17 @@ func (c *Client) GetChatInfo(chatID string) (*Chat, error) {
 	params := url.Values{
 		"chatId": {chatID},
 	}
-	response, err := c.Do("/chats/getInfo", params, nil)
+	response, err := c.DoWithContext(ctx, "/chats/getInfo", params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error while receiving information: %s", err)
+		return nil, fmt.Errorf("error while receiving information: %w", err)
 	}
 
 	chat := &Chat{
 		client: c,
 		ID:     chatID,
 	}
-	if err := json.Unmarshal(response, chat); err != nil {
-		return nil, fmt.Errorf("error while unmarshalling information: %s", err)
+	if err := unmarshalResponse("/chats/getInfo", response, chat); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling information: %w", err)
 	}
 
 	if chat.Type == Private {
@@ -228,6 +368,10 @@ func (c *Client) GetChatInfo(chatID string) (*Chat, error) {
 }
 
 func (c *Client) SendChatActions(chatID string, actions ...ChatAction) error {
+	return c.SendChatActionsWithContext(context.Background(), chatID, actions...)
+}
+
+func (c *Client) SendChatActionsWithContext(ctx context.Context, chatID string, actions ...ChatAction) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -247,14 +391,18 @@ func (c *Client) SendChatActions(chatID string, actions ...ChatAction) error {
 		"chatId":  {chatID},
 		"actions": filteredActions,
 	}
-	_, err := c.Do("/chats/sendActions", params, nil)
+	_, err := c.DoWithContext(ctx, "/chats/sendActions", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while receiving information: %s", err)
+		return fmt.Errorf("error while receiving information: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) GetChatAdmins(chatID string) ([]ChatMember, error) {
+	return c.GetChatAdminsWithContext(context.Background(), chatID)
+}
+
+func (c *Client) GetChatAdminsWithContext(ctx context.Context, chatID string) ([]ChatMember, error) {
 	if chatID == "" {
 		return nil, fmt.Errorf("chatID cannot be empty")
 	}
@@ -263,19 +411,23 @@ func (c *Client) GetChatAdmins(chatID string) ([]ChatMember, error) {
 		"chatId": {chatID},
 	}
 
-	response, err := c.Do("/chats/getAdmins", params, nil)
+	response, err := c.DoWithContext(ctx, "/chats/getAdmins", params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error while receiving admins: %s", err)
+		return nil, fmt.Errorf("error while receiving admins: %w", err)
 	}
 
 	admins := new(AdminsListResponse)
-	if err := json.Unmarshal(response, admins); err != nil {
-		return nil, fmt.Errorf("error while unmarshalling admins: %s", err)
+	if err := unmarshalResponse("/chats/getAdmins", response, admins); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling admins: %w", err)
 	}
 	return admins.List, nil
 }
 
 func (c *Client) GetChatMembers(chatID string) ([]ChatMember, error) {
+	return c.GetChatMembersWithContext(context.Background(), chatID)
+}
+
+func (c *Client) GetChatMembersWithContext(ctx context.Context, chatID string) ([]ChatMember, error) {
 	if chatID == "" {
 		return nil, fmt.Errorf("chatID cannot be empty")
 	}
@@ -284,19 +436,23 @@ func (c *Client) GetChatMembers(chatID string) ([]ChatMember, error) {
 		"chatId": {chatID},
 	}
 
-	response, err := c.Do("/chats/getMembers", params, nil)
+	response, err := c.DoWithContext(ctx, "/chats/getMembers", params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error while receiving members: %s", err)
+		return nil, fmt.Errorf("error while receiving members: %w", err)
 	}
 
 	members := new(MembersListResponse)
-	if err := json.Unmarshal(response, members); err != nil {
-		return nil, fmt.Errorf("error while unmarshalling members: %s", err)
+	if err := unmarshalResponse("/chats/getMembers", response, members); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling members: %w", err)
 	}
 	return members.List, nil
 }
 
 func (c *Client) GetChatBlockedUsers(chatID string) ([]User, error) {
+	return c.GetChatBlockedUsersWithContext(context.Background(), chatID)
+}
+
+func (c *Client) GetChatBlockedUsersWithContext(ctx context.Context, chatID string) ([]User, error) {
 	if chatID == "" {
 		return nil, fmt.Errorf("chatID cannot be empty")
 	}
@@ -305,19 +461,23 @@ func (c *Client) GetChatBlockedUsers(chatID string) ([]User, error) {
 		"chatId": {chatID},
 	}
 
-	response, err := c.Do("/chats/getBlockedUsers", params, nil)
+	response, err := c.DoWithContext(ctx, "/chats/getBlockedUsers", params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error while receiving blocked users: %s", err)
+		return nil, fmt.Errorf("error while receiving blocked users: %w", err)
 	}
 
 	users := new(UsersListResponse)
-	if err := json.Unmarshal(response, users); err != nil {
-		return nil, fmt.Errorf("error while unmarshalling blocked users: %s", err)
+	if err := unmarshalResponse("/chats/getBlockedUsers", response, users); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling blocked users: %w", err)
 	}
 	return users.List, nil
 }
 
 func (c *Client) GetChatPendingUsers(chatID string) ([]User, error) {
+	return c.GetChatPendingUsersWithContext(context.Background(), chatID)
+}
+
+func (c *Client) GetChatPendingUsersWithContext(ctx context.Context, chatID string) ([]User, error) {
 	if chatID == "" {
 		return nil, fmt.Errorf("chatID cannot be empty")
 	}
@@ -326,19 +486,23 @@ func (c *Client) GetChatPendingUsers(chatID string) ([]User, error) {
 		"chatId": {chatID},
 	}
 
-	response, err := c.Do("/chats/getPendingUsers", params, nil)
+	response, err := c.DoWithContext(ctx, "/chats/getPendingUsers", params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error while receiving pending users: %s", err)
+		return nil, fmt.Errorf("error while receiving pending users: %w", err)
 	}
 
 	users := new(UsersListResponse)
-	if err := json.Unmarshal(response, users); err != nil {
-		return nil, fmt.Errorf("error while unmarshalling pending users: %s", err)
+	if err := unmarshalResponse("/chats/getPendingUsers", response, users); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling pending users: %w", err)
 	}
 	return users.List, nil
 }
 
 func (c *Client) BlockChatUser(chatID, userID string, deleteLastMessages bool) error {
+	return c.BlockChatUserWithContext(context.Background(), chatID, userID, deleteLastMessages)
+}
+
+func (c *Client) BlockChatUserWithContext(ctx context.Context, chatID, userID string, deleteLastMessages bool) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -352,19 +516,23 @@ func (c *Client) BlockChatUser(chatID, userID string, deleteLastMessages bool) e
 		"delLastMessages": {strconv.FormatBool(deleteLastMessages)},
 	}
 
-	response, err := c.Do("/chats/blockUser", params, nil)
+	response, err := c.DoWithContext(ctx, "/chats/blockUser", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while blocking user: %s", err)
+		return fmt.Errorf("error while blocking user: %w", err)
 	}
 
 	users := new(UsersListResponse)
-	if err := json.Unmarshal(response, users); err != nil {
-		return fmt.Errorf("error while blocking user: %s", err)
+	if err := unmarshalResponse("/chats/blockUser", response, users); err != nil {
+		return fmt.Errorf("error while blocking user: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) UnblockChatUser(chatID, userID string) error {
+	return c.UnblockChatUserWithContext(context.Background(), chatID, userID)
+}
+
+func (c *Client) UnblockChatUserWithContext(ctx context.Context, chatID, userID string) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -377,19 +545,23 @@ func (c *Client) UnblockChatUser(chatID, userID string) error {
 		"userId": {userID},
 	}
 
-	response, err := c.Do("/chats/unblockUser", params, nil)
+	response, err := c.DoWithContext(ctx, "/chats/unblockUser", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while unblocking user: %s", err)
+		return fmt.Errorf("error while unblocking user: %w", err)
 	}
 
 	users := new(UsersListResponse)
-	if err := json.Unmarshal(response, users); err != nil {
-		return fmt.Errorf("error while unblocking user: %s", err)
+	if err := unmarshalResponse("/chats/unblockUser", response, users); err != nil {
+		return fmt.Errorf("error while unblocking user: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) ResolveChatPending(chatID, userID string, approve, everyone bool) error {
+	return c.ResolveChatPendingWithContext(context.Background(), chatID, userID, approve, everyone)
+}
+
+func (c *Client) ResolveChatPendingWithContext(ctx context.Context, chatID, userID string, approve, everyone bool) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -404,13 +576,17 @@ func (c *Client) ResolveChatPending(chatID, userID string, approve, everyone boo
 		params.Set("userId", userID)
 	}
 
-	if _, err := c.Do("/chats/resolvePending", params, nil); err != nil {
-		return fmt.Errorf("error while resolving chat pendings: %s", err)
+	if _, err := c.DoWithContext(ctx, "/chats/resolvePending", params, nil); err != nil {
+		return fmt.Errorf("error while resolving chat pendings: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) DeleteChatMembers(chatID string, members []string) error {
+	return c.DeleteChatMembersWithContext(context.Background(), chatID, members)
+}
+
+func (c *Client) DeleteChatMembersWithContext(ctx context.Context, chatID string, members []string) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -425,7 +601,7 @@ func (c *Client) DeleteChatMembers(chatID string, members []string) error {
 
 	membersJSON, err := json.Marshal(membersList)
 	if err != nil {
-		return fmt.Errorf("error while marshalling members list: %s", err)
+		return fmt.Errorf("error while marshalling members list: %w", err)
 	}
 
 	params := url.Values{
@@ -433,13 +609,17 @@ func (c *Client) DeleteChatMembers(chatID string, members []string) error {
 		"members": {string(membersJSON)},
 	}
 
-	if _, err := c.Do("/chats/members/delete", params, nil); err != nil {
-		return fmt.Errorf("error while deleting chat members: %s", err)
+	if _, err := c.DoWithContext(ctx, "/chats/members/delete", params, nil); err != nil {
+		return fmt.Errorf("error while deleting chat members: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) AddChatMembers(chatID string, members []string) error {
+	return c.AddChatMembersWithContext(context.Background(), chatID, members)
+}
+
+func (c *Client) AddChatMembersWithContext(ctx context.Context, chatID string, members []string) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -454,7 +634,7 @@ func (c *Client) AddChatMembers(chatID string, members []string) error {
 
 	membersJSON, err := json.Marshal(membersList)
 	if err != nil {
-		return fmt.Errorf("error while marshalling members list: %s", err)
+		return fmt.Errorf("error while marshalling members list: %w", err)
 	}
 
 	params := url.Values{
@@ -462,13 +642,17 @@ func (c *Client) AddChatMembers(chatID string, members []string) error {
 		"members": {string(membersJSON)},
 	}
 
-	if _, err := c.Do("/chats/members/add", params, nil); err != nil {
-		return fmt.Errorf("error while adding chat members: %s", err)
+	if _, err := c.DoWithContext(ctx, "/chats/members/add", params, nil); err != nil {
+		return fmt.Errorf("error while adding chat members: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) SetChatTitle(chatID, title string) error {
+	return c.SetChatTitleWithContext(context.Background(), chatID, title)
+}
+
+func (c *Client) SetChatTitleWithContext(ctx context.Context, chatID, title string) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -481,13 +665,17 @@ func (c *Client) SetChatTitle(chatID, title string) error {
 		"title":  {title},
 	}
 
-	if _, err := c.Do("/chats/setTitle", params, nil); err != nil {
-		return fmt.Errorf("error while setting chat title: %s", err)
+	if _, err := c.DoWithContext(ctx, "/chats/setTitle", params, nil); err != nil {
+		return fmt.Errorf("error while setting chat title: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) SetChatAbout(chatID, about string) error {
+	return c.SetChatAboutWithContext(context.Background(), chatID, about)
+}
+
+func (c *Client) SetChatAboutWithContext(ctx context.Context, chatID, about string) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -497,13 +685,17 @@ func (c *Client) SetChatAbout(chatID, about string) error {
 		"about":  {about},
 	}
 
-	if _, err := c.Do("/chats/setAbout", params, nil); err != nil {
-		return fmt.Errorf("error while setting chat about: %s", err)
+	if _, err := c.DoWithContext(ctx, "/chats/setAbout", params, nil); err != nil {
+		return fmt.Errorf("error while setting chat about: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) SetChatRules(chatID, rules string) error {
+	return c.SetChatRulesWithContext(context.Background(), chatID, rules)
+}
+
+func (c *Client) SetChatRulesWithContext(ctx context.Context, chatID, rules string) error {
 	if chatID == "" {
 		return fmt.Errorf("chatID cannot be empty")
 	}
@@ -513,13 +705,17 @@ func (c *Client) SetChatRules(chatID, rules string) error {
 		"rules":  {rules},
 	}
 
-	if _, err := c.Do("/chats/setRules", params, nil); err != nil {
-		return fmt.Errorf("error while setting chat rules: %s", err)
+	if _, err := c.DoWithContext(ctx, "/chats/setRules", params, nil); err != nil {
+		return fmt.Errorf("error while setting chat rules: %w", err)
 	}
 	return nil
 }
 
 func (c *Client) GetFileInfo(fileID string) (*File, error) {
+	return c.GetFileInfoWithContext(context.Background(), fileID)
+}
+
+func (c *Client) GetFileInfoWithContext(ctx context.Context, fileID string) (*File, error) {
 	if fileID == "" {
 		return nil, fmt.Errorf("fileID cannot be empty")
 	}
@@ -527,24 +723,32 @@ func (c *Client) GetFileInfo(fileID string) (*File, error) {
 	params := url.Values{
 		"fileId": {fileID},
 	}
-	response, err := c.Do("/files/getInfo", params, nil)
+	response, err := c.DoWithContext(ctx, "/files/getInfo", params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error while receiving information: %s", err)
+		return nil, fmt.Errorf("error while receiving information: %w", err)
 	}
 
 	file := &File{}
-	if err := json.Unmarshal(response, file); err != nil {
-		return nil, fmt.Errorf("error while unmarshalling information: %s", err)
+	if err := unmarshalResponse("/files/getInfo", response, file); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling information: %w", err)
 	}
 
 	return file, nil
 }
 
 func (c *Client) GetVoiceInfo(fileID string) (*File, error) {
-	return c.GetFileInfo(fileID)
+	return c.GetVoiceInfoWithContext(context.Background(), fileID)
+}
+
+func (c *Client) GetVoiceInfoWithContext(ctx context.Context, fileID string) (*File, error) {
+	return c.GetFileInfoWithContext(ctx, fileID)
 }
 
 func (c *Client) SendTextMessage(message *Message) error {
+	return c.SendTextMessageWithContext(context.Background(), message)
+}
+
+func (c *Client) SendTextMessageWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -558,7 +762,7 @@ func (c *Client) SendTextMessage(message *Message) error {
 	params := url.Values{
 		"chatId":     {message.Chat.ID},
 		"text":       {message.Text},
-		"request-id": {message.RequestID},
+		"request-id": {messageRequestID(ctx, message)},
 	}
 
 	if message.ReplyMsgID != "" {
@@ -573,7 +777,7 @@ func (c *Client) SendTextMessage(message *Message) error {
 	if message.InlineKeyboard != nil {
 		data, err := json.Marshal(message.InlineKeyboard.GetKeyboard())
 		if err != nil {
-			return fmt.Errorf("cannot marshal inline keyboard markup: %s", err)
+			return fmt.Errorf("cannot marshal inline keyboard markup: %w", err)
 		}
 
 		params.Set("inlineKeyboardMarkup", string(data))
@@ -583,19 +787,27 @@ func (c *Client) SendTextMessage(message *Message) error {
 		params.Set("parseMode", string(message.ParseMode))
 	}
 
-	response, err := c.Do("/messages/sendText", params, nil)
+	if err := setFormat(params, message); err != nil {
+		return err
+	}
+
+	response, err := c.DoWithContext(ctx, "/messages/sendText", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while sending text: %s", err)
+		return fmt.Errorf("error while sending text: %w", err)
 	}
 
-	if err := json.Unmarshal(response, message); err != nil {
-		return fmt.Errorf("cannot unmarshal response from API: %s", err)
+	if err := unmarshalResponse("/messages/sendText", response, message); err != nil {
+		return fmt.Errorf("cannot unmarshal response from API: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) SendTextWithDeeplinkMessage(message *Message) error {
+	return c.SendTextWithDeeplinkMessageWithContext(context.Background(), message)
+}
+
+func (c *Client) SendTextWithDeeplinkMessageWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -609,7 +821,7 @@ func (c *Client) SendTextWithDeeplinkMessage(message *Message) error {
 	params := url.Values{
 		"chatId":     {message.Chat.ID},
 		"text":       {message.Text},
-		"request-id": {message.RequestID},
+		"request-id": {messageRequestID(ctx, message)},
 	}
 
 	if message.ReplyMsgID != "" {
@@ -624,7 +836,7 @@ func (c *Client) SendTextWithDeeplinkMessage(message *Message) error {
 	if message.InlineKeyboard != nil {
 		data, err := json.Marshal(message.InlineKeyboard.GetKeyboard())
 		if err != nil {
-			return fmt.Errorf("cannot marshal inline keyboard markup: %s", err)
+			return fmt.Errorf("cannot marshal inline keyboard markup: %w", err)
 		}
 
 		params.Set("inlineKeyboardMarkup", string(data))
@@ -639,19 +851,27 @@ func (c *Client) SendTextWithDeeplinkMessage(message *Message) error {
 		params.Set("parseMode", string(message.ParseMode))
 	}
 
-	response, err := c.Do("/messages/sendTextWithDeeplink", params, nil)
+	if err := setFormat(params, message); err != nil {
+		return err
+	}
+
+	response, err := c.DoWithContext(ctx, "/messages/sendTextWithDeeplink", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while sending text: %s", err)
+		return fmt.Errorf("error while sending text: %w", err)
 	}
 
-	if err := json.Unmarshal(response, message); err != nil {
-		return fmt.Errorf("cannot unmarshal response from API: %s", err)
+	if err := unmarshalResponse("/messages/sendTextWithDeeplink", response, message); err != nil {
+		return fmt.Errorf("cannot unmarshal response from API: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) EditMessage(message *Message) error {
+	return c.EditMessageWithContext(context.Background(), message)
+}
+
+func (c *Client) EditMessageWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -674,7 +894,7 @@ func (c *Client) EditMessage(message *Message) error {
 	if message.InlineKeyboard != nil {
 		data, err := json.Marshal(message.InlineKeyboard.GetKeyboard())
 		if err != nil {
-			return fmt.Errorf("cannot marshal inline keyboard markup: %s", err)
+			return fmt.Errorf("cannot marshal inline keyboard markup: %w", err)
 		}
 
 		params.Set("inlineKeyboardMarkup", string(data))
@@ -684,19 +904,27 @@ func (c *Client) EditMessage(message *Message) error {
 		params.Set("parseMode", string(message.ParseMode))
 	}
 
-	response, err := c.Do("/messages/editText", params, nil)
+	if err := setFormat(params, message); err != nil {
+		return err
+	}
+
+	response, err := c.DoWithContext(ctx, "/messages/editText", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while editing text: %s", err)
+		return fmt.Errorf("error while editing text: %w", err)
 	}
 
-	if err := json.Unmarshal(response, message); err != nil {
-		return fmt.Errorf("cannot unmarshal response from API: %s", err)
+	if err := unmarshalResponse("/messages/editText", response, message); err != nil {
+		return fmt.Errorf("cannot unmarshal response from API: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) DeleteMessage(message *Message) error {
+	return c.DeleteMessageWithContext(context.Background(), message)
+}
+
+func (c *Client) DeleteMessageWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -711,15 +939,19 @@ func (c *Client) DeleteMessage(message *Message) error {
 		"msgId":  {message.ID},
 		"chatId": {message.Chat.ID},
 	}
-	_, err := c.Do("/messages/deleteMessages", params, nil)
+	_, err := c.DoWithContext(ctx, "/messages/deleteMessages", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while deleting message: %s", err)
+		return fmt.Errorf("error while deleting message: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) SendFileMessage(message *Message) error {
+	return c.SendFileMessageWithContext(context.Background(), message)
+}
+
+func (c *Client) SendFileMessageWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -748,7 +980,7 @@ func (c *Client) SendFileMessage(message *Message) error {
 	if message.InlineKeyboard != nil {
 		data, err := json.Marshal(message.InlineKeyboard.GetKeyboard())
 		if err != nil {
-			return fmt.Errorf("cannot marshal inline keyboard markup: %s", err)
+			return fmt.Errorf("cannot marshal inline keyboard markup: %w", err)
 		}
 
 		params.Set("inlineKeyboardMarkup", string(data))
@@ -758,19 +990,27 @@ func (c *Client) SendFileMessage(message *Message) error {
 		params.Set("parseMode", string(message.ParseMode))
 	}
 
-	response, err := c.Do("/messages/sendFile", params, nil)
+	if err := setFormat(params, message); err != nil {
+		return err
+	}
+
+	response, err := c.DoWithContext(ctx, "/messages/sendFile", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while making request: %s", err)
+		return fmt.Errorf("error while making request: %w", err)
 	}
 
-	if err := json.Unmarshal(response, message); err != nil {
-		return fmt.Errorf("cannot unmarshal response: %s", err)
+	if err := unmarshalResponse("/messages/sendFile", response, message); err != nil {
+		return fmt.Errorf("cannot unmarshal response: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) SendVoiceMessage(message *Message) error {
+	return c.SendVoiceMessageWithContext(context.Background(), message)
+}
+
+func (c *Client) SendVoiceMessageWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -799,25 +1039,33 @@ func (c *Client) SendVoiceMessage(message *Message) error {
 	if message.InlineKeyboard != nil {
 		data, err := json.Marshal(message.InlineKeyboard.GetKeyboard())
 		if err != nil {
-			return fmt.Errorf("cannot marshal inline keyboard markup: %s", err)
+			return fmt.Errorf("cannot marshal inline keyboard markup: %w", err)
 		}
 
 		params.Set("inlineKeyboardMarkup", string(data))
 	}
 
-	response, err := c.Do("/messages/sendVoice", params, nil)
+	if err := setFormat(params, message); err != nil {
+		return err
+	}
+
+	response, err := c.DoWithContext(ctx, "/messages/sendVoice", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while making request: %s", err)
+		return fmt.Errorf("error while making request: %w", err)
 	}
 
-	if err := json.Unmarshal(response, message); err != nil {
-		return fmt.Errorf("cannot unmarshal response: %s", err)
+	if err := unmarshalResponse("/messages/sendVoice", response, message); err != nil {
+		return fmt.Errorf("cannot unmarshal response: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) UploadFile(message *Message) error {
+	return c.UploadFileWithContext(context.Background(), message)
+}
+
+func (c *Client) UploadFileWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -836,25 +1084,37 @@ func (c *Client) UploadFile(message *Message) error {
 	if message.InlineKeyboard != nil {
 		data, err := json.Marshal(message.InlineKeyboard.GetKeyboard())
 		if err != nil {
-			return fmt.Errorf("cannot marshal inline keyboard markup: %s", err)
+			return fmt.Errorf("cannot marshal inline keyboard markup: %w", err)
 		}
 
 		params.Set("inlineKeyboardMarkup", string(data))
 	}
 
-	response, err := c.Do("/messages/sendFile", params, message.File)
+	if message.ParseMode != "" {
+		params.Set("parseMode", string(message.ParseMode))
+	}
+
+	if err := setFormat(params, message); err != nil {
+		return err
+	}
+
+	response, err := c.DoWithContext(ctx, "/messages/sendFile", params, message.File)
 	if err != nil {
-		return fmt.Errorf("error while making request: %s", err)
+		return fmt.Errorf("error while making request: %w", err)
 	}
 
-	if err := json.Unmarshal(response, message); err != nil {
-		return fmt.Errorf("cannot unmarshal response: %s", err)
+	if err := unmarshalResponse("/messages/sendFile", response, message); err != nil {
+		return fmt.Errorf("cannot unmarshal response: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) UploadVoice(message *Message) error {
+	return c.UploadVoiceWithContext(context.Background(), message)
+}
+
+func (c *Client) UploadVoiceWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -873,19 +1133,23 @@ func (c *Client) UploadVoice(message *Message) error {
 	if message.InlineKeyboard != nil {
 		data, err := json.Marshal(message.InlineKeyboard.GetKeyboard())
 		if err != nil {
-			return fmt.Errorf("cannot marshal inline keyboard markup: %s", err)
+			return fmt.Errorf("cannot marshal inline keyboard markup: %w", err)
 		}
 
 		params.Set("inlineKeyboardMarkup", string(data))
 	}
 
-	response, err := c.Do("/messages/sendVoice", params, message.File)
+	if err := setFormat(params, message); err != nil {
+		return err
+	}
+
+	response, err := c.DoWithContext(ctx, "/messages/sendVoice", params, message.File)
 	if err != nil {
-		return fmt.Errorf("error while making request: %s", err)
+		return fmt.Errorf("error while making request: %w", err)
 	}
 
-	if err := json.Unmarshal(response, message); err != nil {
-		return fmt.Errorf("cannot unmarshal response: %s", err)
+	if err := unmarshalResponse("/messages/sendVoice", response, message); err != nil {
+		return fmt.Errorf("cannot unmarshal response: %w", err)
 	}
 
 	return nil
@@ -904,17 +1168,21 @@ func (c *Client) GetEventsWithContext(ctx context.Context, lastEventID int, poll
 
 	response, err := c.DoWithContext(ctx, "/events/get", params, nil)
 	if err != nil {
-		return events.Events, fmt.Errorf("error while making request: %s", err)
+		return events.Events, fmt.Errorf("error while making request: %w", err)
 	}
 
-	if err := json.Unmarshal(response, events); err != nil {
-		return events.Events, fmt.Errorf("cannot parse events: %s", err)
+	if err := unmarshalResponse("/events/get", response, events); err != nil {
+		return events.Events, fmt.Errorf("cannot parse events: %w", err)
 	}
 
 	return events.Events, nil
 }
 
 func (c *Client) PinMessage(message *Message) error {
+	return c.PinMessageWithContext(context.Background(), message)
+}
+
+func (c *Client) PinMessageWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -929,15 +1197,19 @@ func (c *Client) PinMessage(message *Message) error {
 		"chatId": {message.Chat.ID},
 		"msgId":  {message.ID},
 	}
-	_, err := c.Do("/chats/pinMessage", params, nil)
+	_, err := c.DoWithContext(ctx, "/chats/pinMessage", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while pinning message: %s", err)
+		return fmt.Errorf("error while pinning message: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) UnpinMessage(message *Message) error {
+	return c.UnpinMessageWithContext(context.Background(), message)
+}
+
+func (c *Client) UnpinMessageWithContext(ctx context.Context, message *Message) error {
 	if message == nil {
 		return fmt.Errorf("message cannot be nil")
 	}
@@ -952,15 +1224,19 @@ func (c *Client) UnpinMessage(message *Message) error {
 		"chatId": {message.Chat.ID},
 		"msgId":  {message.ID},
 	}
-	_, err := c.Do("/chats/unpinMessage", params, nil)
+	_, err := c.DoWithContext(ctx, "/chats/unpinMessage", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while unpinning message: %s", err)
+		return fmt.Errorf("error while unpinning message: %w", err)
 	}
 
 	return nil
 }
 
 func (c *Client) SendAnswerCallbackQuery(answer *ButtonResponse) error {
+	return c.SendAnswerCallbackQueryWithContext(context.Background(), answer)
+}
+
+func (c *Client) SendAnswerCallbackQueryWithContext(ctx context.Context, answer *ButtonResponse) error {
 	if answer == nil {
 		return fmt.Errorf("answer cannot be nil")
 	}
@@ -975,11 +1251,12 @@ func (c *Client) SendAnswerCallbackQuery(answer *ButtonResponse) error {
 		"showAlert": {strconv.FormatBool(answer.ShowAlert)},
 	}
 
-	_, err := c.Do("/messages/answerCallbackQuery", params, nil)
+	_, err := c.DoWithContext(ctx, "/messages/answerCallbackQuery", params, nil)
 	if err != nil {
-		return fmt.Errorf("error while making request: %s", err)
+		return fmt.Errorf("error while making request: %w", err)
 	}
 
+	answer.answered = true
 	return nil
 }
 
@@ -987,7 +1264,18 @@ func NewClient(baseURL string, token string, logger *logrus.Logger) *Client {
 	return NewCustomClient(http.DefaultClient, baseURL, token, logger)
 }
 
+// NewCustomClient returns new client with the http client, it writes logs to the logrus logger.
+// Use NewCustomClientWithLogger for other loggers.
 func NewCustomClient(client *http.Client, baseURL string, token string, logger *logrus.Logger) *Client {
+	return NewCustomClientWithLogger(client, baseURL, token, NewLogrusLogger(logger))
+}
+
+// NewCustomClientWithLogger returns new client with the http client writing logs to the logger
+func NewCustomClientWithLogger(client *http.Client, baseURL string, token string, logger Logger) *Client {
+	if logger == nil {
+		logger = noopLogger{}
+	}
+
 	return &Client{
 		token:   token,
 		baseURL: baseURL,
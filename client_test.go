@@ -298,6 +298,27 @@ func TestClient_DoWithContext_WithFileUpload(t *testing.T) {
 	require.JSONEq(t, `{"ok":true,"msgId":"voice123","timestamp":123456}`, string(bytes))
 }
 
+func TestClient_UploadFile_ParseMode(t *testing.T) {
+	client := NewApiMockClient(t)
+
+	var params url.Values
+	client.interceptors = []Interceptor{
+		func(ctx context.Context, call *APICall, next Invoker) (*APIResult, error) {
+			params = call.Params
+			return next(ctx, call)
+		},
+	}
+
+	message := &Message{
+		Chat:      Chat{ID: "chat"},
+		File:      NewUploadFileFromReader("test.txt", strings.NewReader("content")),
+		Text:      "<b>caption</b>",
+		ParseMode: ParseModeHTML,
+	}
+	require.NoError(t, client.UploadFile(message))
+	require.Equal(t, "HTML", params.Get("parseMode"))
+}
+
 func TestClient_Do_WithEmptyFile_Error(t *testing.T) {
 	client := NewApiMockClient(t)
 
@@ -348,3 +369,13 @@ func TestClient_Do_SendVoice_Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetChatInfoWithContext_Canceled(t *testing.T) {
+	client := NewApiMockClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetChatInfoWithContext(ctx, "id_1234")
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,377 @@
+package botgolang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"runtime/debug"
+	"sync"
+)
+
+const (
+	defaultDispatcherWorkers   = 8
+	defaultDispatcherQueueSize = 64
+)
+
+// ErrHandlerPanic is reported to DispatcherConfig.OnError when a handler panics
+var ErrHandlerPanic = errors.New("handler panicked")
+
+// Handler handles events received by the bot
+type Handler interface {
+	Handle(ctx context.Context, event Event) error
+}
+
+// HandlerFunc is an adapter to use functions as handlers
+type HandlerFunc func(ctx context.Context, event Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// OverflowPolicy decides what to do with an event when the queue of its chat is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until the queue has free space, it slows down receiving of events for all chats
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropNewest drops the incoming event
+	OverflowDropNewest
+
+	// OverflowDropOldest drops the oldest queued event to free space for the incoming one
+	OverflowDropOldest
+)
+
+// DispatcherConfig configures the dispatcher
+type DispatcherConfig struct {
+	// Number of workers handling events, defaults to 8.
+	// Events of one chat are always handled by the same worker.
+	Workers int
+
+	// Number of events waiting for each worker, defaults to 64
+	QueueSize int
+
+	// What to do when the queue is full, defaults to OverflowBlock
+	Overflow OverflowPolicy
+
+	// OnDrop is called for events dropped because of the overflow policy
+	OnDrop func(event Event)
+
+	// OnError is called when a handler returns an error or panics
+	OnError func(event Event, err error)
+
+	// Logger for handler errors, the bot logger is used by Bot.Dispatch
+	Logger Logger
+}
+
+// Dispatcher handles events concurrently with a fixed number of workers.
+// Events are sharded by chat, so events of one chat are handled in order of receiving
+// while events of different chats are handled in parallel.
+type Dispatcher struct {
+	handler Handler
+	config  DispatcherConfig
+	queues  []chan Event
+	wg      sync.WaitGroup
+
+	quit chan struct{}
+	done chan struct{}
+
+	// mu guards the state of shutdown, stopping is set together with closing quit
+	mu       sync.Mutex
+	idle     sync.Cond
+	started  bool
+	stopping bool
+
+	// active counts workers and the dispatching loop which can still take events,
+	// Shutdown waits until they stop, so all abandoned events get to the report
+	active    int
+	running   int
+	unhandled []Event
+}
+
+// NewDispatcher returns new dispatcher calling the handler for every event
+func NewDispatcher(handler Handler, config DispatcherConfig) *Dispatcher {
+	if config.Workers < 1 {
+		config.Workers = defaultDispatcherWorkers
+	}
+	if config.QueueSize < 1 {
+		config.QueueSize = defaultDispatcherQueueSize
+	}
+	if config.Logger == nil {
+		config.Logger = noopLogger{}
+	}
+
+	// queues are created before Run, so Shutdown can drain them at any time
+	queues := make([]chan Event, config.Workers)
+	for i := range queues {
+		queues[i] = make(chan Event, config.QueueSize)
+	}
+
+	d := &Dispatcher{
+		handler: handler,
+		config:  config,
+		queues:  queues,
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	d.idle.L = &d.mu
+	return d
+}
+
+// Run dispatches events from the channel until it is closed,
+// then it waits until all queued events are handled.
+// Handled and dropped events are acknowledged with Event.Ack.
+func (d *Dispatcher) Run(events <-chan Event) {
+	defer close(d.done)
+
+	d.mu.Lock()
+	d.started = true
+	d.active = len(d.queues)
+	d.mu.Unlock()
+
+	for _, queue := range d.queues {
+		d.wg.Add(1)
+		go d.work(queue)
+	}
+
+	for event := range events {
+		if !d.enter(event) {
+			continue
+		}
+		d.dispatch(event)
+		d.leave()
+	}
+
+	for _, queue := range d.queues {
+		close(queue)
+	}
+	d.wg.Wait()
+}
+
+// enter marks the caller active before it takes the event,
+// it returns false and abandons the event if the dispatcher is stopping
+func (d *Dispatcher) enter(event Event) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopping {
+		d.abandonLocked(event)
+		return false
+	}
+	d.active++
+	return true
+}
+
+// leave marks the caller inactive, Shutdown waiting for the report is woken up
+func (d *Dispatcher) leave() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.active--
+	d.idle.Broadcast()
+}
+
+// dispatch puts the event to the queue of its chat according to the overflow policy
+func (d *Dispatcher) dispatch(event Event) {
+	queue := d.queues[d.shard(event)]
+
+	switch d.config.Overflow {
+	case OverflowDropNewest:
+		select {
+		case queue <- event:
+		default:
+			d.drop(event)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case queue <- event:
+				return
+			default:
+			}
+
+			select {
+			case oldest := <-queue:
+				d.drop(oldest)
+			default:
+			}
+		}
+	default:
+		select {
+		case queue <- event:
+		case <-d.quit:
+			d.abandon(event)
+		}
+	}
+}
+
+func (d *Dispatcher) shard(event Event) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(event.ChatID()))
+	return int(hash.Sum32() % uint32(len(d.queues)))
+}
+
+func (d *Dispatcher) drop(event Event) {
+	d.config.Logger.Log(LogLevelWarn, "event dropped, queue is full", LogFields{
+		"event_id": event.EventID,
+		"chat_id":  event.ChatID(),
+	})
+	if d.config.OnDrop != nil {
+		d.config.OnDrop(event)
+	}
+	d.ack(event)
+}
+
+// work handles events of the queue, it is active while it waits for events and inactive while it runs the handler
+func (d *Dispatcher) work(queue <-chan Event) {
+	defer d.wg.Done()
+	defer d.leave()
+
+	for {
+		var event Event
+		var ok bool
+		select {
+		case <-d.quit:
+			return
+		case event, ok = <-queue:
+		}
+		if !ok {
+			return
+		}
+
+		d.mu.Lock()
+		if d.stopping {
+			d.abandonLocked(event)
+			d.mu.Unlock()
+			continue
+		}
+		d.active--
+		d.running++
+		d.mu.Unlock()
+
+		err := d.handle(event)
+
+		if err != nil {
+			d.config.Logger.Log(LogLevelError, "cannot handle event", LogFields{
+				"err":      err,
+				"event_id": event.EventID,
+				"chat_id":  event.ChatID(),
+			})
+			if d.config.OnError != nil {
+				d.config.OnError(event, err)
+			}
+			event.span.end(err)
+		}
+		d.ack(event)
+
+		d.mu.Lock()
+		d.running--
+		d.active++
+		d.mu.Unlock()
+	}
+}
+
+// Shutdown waits until the events channel is closed and all queued events are handled.
+// If the context is done first, queued events are not handled anymore, they are returned in the report
+// together with the number of handlers which are still running. If Run has not started, it returns at once.
+func (d *Dispatcher) Shutdown(ctx context.Context) (*ShutdownReport, error) {
+	d.mu.Lock()
+	started := d.started
+	if !started {
+		d.stop()
+	}
+	d.mu.Unlock()
+	if !started {
+		return &ShutdownReport{}, nil
+	}
+
+	select {
+	case <-d.done:
+		return &ShutdownReport{}, nil
+	case <-ctx.Done():
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// after stop no events are taken from the queues, the rest of them is abandoned here
+	d.stop()
+	for d.active > 0 {
+		d.idle.Wait()
+	}
+	for _, queue := range d.queues {
+		d.drainLocked(queue)
+	}
+
+	return &ShutdownReport{
+		Unhandled: append([]Event(nil), d.unhandled...),
+		Running:   d.running,
+	}, ctx.Err()
+}
+
+// stop makes workers abandon events instead of handling them, must be called with the lock held
+func (d *Dispatcher) stop() {
+	if !d.stopping {
+		d.stopping = true
+		close(d.quit)
+	}
+}
+
+// drainLocked abandons events waiting in the queue, must be called with the lock held
+func (d *Dispatcher) drainLocked(queue chan Event) {
+	for {
+		select {
+		case event, ok := <-queue:
+			if !ok {
+				return
+			}
+			d.abandonLocked(event)
+		default:
+			return
+		}
+	}
+}
+
+// abandon records the event which is not handled because of shutdown, it is not acknowledged
+func (d *Dispatcher) abandon(event Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.abandonLocked(event)
+}
+
+func (d *Dispatcher) abandonLocked(event Event) {
+	event.span.end(nil)
+	d.unhandled = append(d.unhandled, event)
+}
+
+// handle calls the handler and recovers its panic.
+// The context of the handler is cancelled when Shutdown reaches its deadline.
+func (d *Dispatcher) handle(event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v\n%s", ErrHandlerPanic, r, debug.Stack())
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(event.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-d.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return d.handler.Handle(ctx, event)
+}
+
+func (d *Dispatcher) ack(event Event) {
+	if err := event.Ack(); err != nil {
+		d.config.Logger.Log(LogLevelError, "cannot acknowledge event", LogFields{
+			"err":      err,
+			"event_id": event.EventID,
+		})
+	}
+}
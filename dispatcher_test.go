@@ -0,0 +1,277 @@
+package botgolang
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDispatcherTestEvent(id int, chatID string) Event {
+	return Event{
+		EventID: id,
+		Type:    NEW_MESSAGE,
+		Payload: EventPayload{BaseEventPayload: BaseEventPayload{Chat: Chat{ID: chatID}}},
+	}
+}
+
+func TestDispatcher_PerChatOrdering(t *testing.T) {
+	var mu sync.Mutex
+	handled := make(map[string][]int)
+	dispatcher := NewDispatcher(HandlerFunc(func(ctx context.Context, event Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		handled[event.Payload.Chat.ID] = append(handled[event.Payload.Chat.ID], event.EventID)
+		return nil
+	}), DispatcherConfig{Workers: 4, QueueSize: 2})
+
+	events := make(chan Event)
+	go func() {
+		for i := 1; i <= 100; i++ {
+			events <- newDispatcherTestEvent(i, "chat"+strconv.Itoa(i%5))
+		}
+		close(events)
+	}()
+	dispatcher.Run(events)
+
+	require.Len(t, handled, 5)
+	for chatID, ids := range handled {
+		assert.Len(t, ids, 20, chatID)
+		assert.IsIncreasing(t, ids, chatID)
+	}
+}
+
+func TestDispatcher_ChatsInParallel(t *testing.T) {
+	dispatcher := NewDispatcher(nil, DispatcherConfig{Workers: 2})
+
+	// find two chats handled by different workers
+	slow, fast := newDispatcherTestEvent(1, "slow"), Event{}
+	for i := 0; fast.EventID == 0; i++ {
+		event := newDispatcherTestEvent(2, "chat"+strconv.Itoa(i))
+		if dispatcher.shard(event) != dispatcher.shard(slow) {
+			fast = event
+		}
+	}
+
+	fastHandled := make(chan struct{})
+	dispatcher.handler = HandlerFunc(func(ctx context.Context, event Event) error {
+		if event.EventID == slow.EventID {
+			<-fastHandled
+			return nil
+		}
+		close(fastHandled)
+		return nil
+	})
+
+	events := make(chan Event, 2)
+	events <- slow
+	events <- fast
+	close(events)
+	dispatcher.Run(events)
+}
+
+func TestDispatcher_ShardCallbackQueries(t *testing.T) {
+	dispatcher := NewDispatcher(nil, DispatcherConfig{Workers: 8})
+
+	callback := func(chatID string) Event {
+		return Event{Type: CALLBACK_QUERY, Payload: EventPayload{CallbackMsg: BaseEventPayload{Chat: Chat{ID: chatID}}}}
+	}
+
+	event := callback("chatA")
+	assert.Equal(t, "chatA", event.ChatID())
+	assert.Equal(t, dispatcher.shard(newDispatcherTestEvent(1, "chatA")), dispatcher.shard(event))
+
+	shards := make(map[int]bool)
+	for i := 0; i < 20; i++ {
+		shards[dispatcher.shard(callback("chat"+strconv.Itoa(i)))] = true
+	}
+	assert.Greater(t, len(shards), 1)
+}
+
+func TestDispatcher_OverflowDropNewest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled []int
+	var dropped []int
+
+	dispatcher := NewDispatcher(HandlerFunc(func(ctx context.Context, event Event) error {
+		if event.EventID == 1 {
+			close(started)
+			<-release
+		}
+		handled = append(handled, event.EventID)
+		return nil
+	}), DispatcherConfig{
+		Workers:   1,
+		QueueSize: 1,
+		Overflow:  OverflowDropNewest,
+		OnDrop: func(event Event) {
+			dropped = append(dropped, event.EventID)
+		},
+	})
+
+	events := make(chan Event)
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(events)
+		close(done)
+	}()
+
+	events <- newDispatcherTestEvent(1, "chat")
+	<-started
+	events <- newDispatcherTestEvent(2, "chat")
+	events <- newDispatcherTestEvent(3, "chat")
+	close(events)
+	close(release)
+	<-done
+
+	assert.Equal(t, []int{1, 2}, handled)
+	assert.Equal(t, []int{3}, dropped)
+}
+
+func TestDispatcher_OverflowDropOldest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled []int
+	var dropped []int
+
+	dispatcher := NewDispatcher(HandlerFunc(func(ctx context.Context, event Event) error {
+		if event.EventID == 1 {
+			close(started)
+			<-release
+		}
+		handled = append(handled, event.EventID)
+		return nil
+	}), DispatcherConfig{
+		Workers:   1,
+		QueueSize: 1,
+		Overflow:  OverflowDropOldest,
+		OnDrop: func(event Event) {
+			dropped = append(dropped, event.EventID)
+		},
+	})
+
+	events := make(chan Event)
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(events)
+		close(done)
+	}()
+
+	events <- newDispatcherTestEvent(1, "chat")
+	<-started
+	events <- newDispatcherTestEvent(2, "chat")
+	events <- newDispatcherTestEvent(3, "chat")
+	close(events)
+	close(release)
+	<-done
+
+	assert.Equal(t, []int{1, 3}, handled)
+	assert.Equal(t, []int{2}, dropped)
+}
+
+func TestDispatcher_RecoverAndAck(t *testing.T) {
+	store := NewMemoryOffsetStore()
+	tracker := newOffsetTracker(store, 0)
+
+	failure := errors.New("failure")
+	var errs []error
+	dispatcher := NewDispatcher(HandlerFunc(func(ctx context.Context, event Event) error {
+		switch event.EventID {
+		case 1:
+			panic("boom")
+		case 2:
+			return failure
+		}
+		return nil
+	}), DispatcherConfig{
+		Workers: 1,
+		OnError: func(event Event, err error) {
+			errs = append(errs, err)
+		},
+	})
+
+	events := make(chan Event, 3)
+	for i := 1; i <= 3; i++ {
+		event := newDispatcherTestEvent(i, "chat")
+		tracker.track(event.EventID)
+		event.offsets = tracker
+		events <- event
+	}
+	close(events)
+	dispatcher.Run(events)
+
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], ErrHandlerPanic)
+	assert.Contains(t, errs[0].Error(), "boom")
+	assert.ErrorIs(t, errs[1], failure)
+
+	offset, _ := store.Load()
+	assert.Equal(t, 3, offset)
+}
+
+func TestDispatcher_Shutdown_NotStarted(t *testing.T) {
+	dispatcher := NewDispatcher(HandlerFunc(func(ctx context.Context, event Event) error {
+		return nil
+	}), DispatcherConfig{Workers: 2})
+
+	report, err := dispatcher.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Lost())
+}
+
+func TestDispatcher_Shutdown_ReportsAllAbandoned(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled []int
+
+	dispatcher := NewDispatcher(HandlerFunc(func(ctx context.Context, event Event) error {
+		if event.EventID == 1 {
+			close(started)
+			<-release
+		}
+		handled = append(handled, event.EventID)
+		return nil
+	}), DispatcherConfig{Workers: 1, QueueSize: 2})
+
+	events := make(chan Event)
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(events)
+		close(done)
+	}()
+
+	events <- newDispatcherTestEvent(1, "chat")
+	<-started
+	// the fourth event waits for free space in the queue
+	for i := 2; i <= 4; i++ {
+		events <- newDispatcherTestEvent(i, "chat")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	report, err := dispatcher.Shutdown(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, report.Running)
+
+	var ids []int
+	for _, event := range report.Unhandled {
+		ids = append(ids, event.EventID)
+	}
+	sort.Ints(ids)
+	assert.Equal(t, []int{2, 3, 4}, ids)
+
+	close(release)
+	close(events)
+	<-done
+
+	assert.Equal(t, []int{1}, handled)
+	assert.Len(t, dispatcher.unhandled, len(report.Unhandled))
+}
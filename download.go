@@ -0,0 +1,334 @@
+package botgolang
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DownloadOption configures DownloadFile and OpenFile
+type DownloadOption func(o *downloadOptions)
+
+type downloadOptions struct {
+	maxSize  int64
+	offset   int64
+	checksum hash.Hash
+	expected []byte
+}
+
+// DownloadMaxSize limits the size of downloaded file, it overrides BotMaxDownloadSize option
+func DownloadMaxSize(size int64) DownloadOption {
+	return func(o *downloadOptions) {
+		o.maxSize = size
+	}
+}
+
+// DownloadOffset starts the download from the given byte, e.g. to continue a partially downloaded file
+func DownloadOffset(offset int64) DownloadOption {
+	return func(o *downloadOptions) {
+		o.offset = offset
+	}
+}
+
+// DownloadChecksum verifies the downloaded data with the hash.
+// If the download starts from an offset, the hash must already contain the data before the offset.
+func DownloadChecksum(h hash.Hash, expected []byte) DownloadOption {
+	return func(o *downloadOptions) {
+		o.checksum = h
+		o.expected = expected
+	}
+}
+
+func (c *Client) newDownloadOptions(opts []DownloadOption) *downloadOptions {
+	options := &downloadOptions{
+		maxSize: c.maxDownloadSize,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// DownloadFile writes the content of the file to w.
+// Interrupted downloads are continued with Range requests according to the client's RetryPolicy.
+// It returns information about the file and an error if the file was not downloaded completely.
+func (c *Client) DownloadFile(ctx context.Context, fileID string, w io.Writer, opts ...DownloadOption) (*File, error) {
+	options := c.newDownloadOptions(opts)
+
+	file, err := c.GetFileInfoWithContext(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := options.checkSize(file); err != nil {
+		return file, err
+	}
+
+	written := options.offset
+	writer := w
+	if options.checksum != nil {
+		writer = io.MultiWriter(w, options.checksum)
+	}
+
+	for attempt := 1; ; attempt++ {
+		n, err := c.downloadPart(ctx, file, written, options.maxSize, writer)
+		written += n
+		if err == nil {
+			break
+		}
+
+		delay, retry := c.retryPolicy.shouldRetry(attempt, DownloadPath, err)
+		if !retry || ctx.Err() != nil {
+			return file, fmt.Errorf("cannot download file %s: %w", fileID, err)
+		}
+
+		c.logger.Log(LogLevelWarn, "download interrupted, retrying", LogFields{
+			"err":     err,
+			"file_id": fileID,
+			"written": written,
+			"attempt": attempt,
+		})
+		c.observeRetry(DownloadPath)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return file, fmt.Errorf("cannot download file %s: %w", fileID, err)
+		}
+	}
+
+	if file.Size > 0 && uint64(written) != file.Size {
+		return file, fmt.Errorf("downloaded %d bytes of %d: %w", written, file.Size, ErrSizeMismatch)
+	}
+	if options.checksum != nil && !bytes.Equal(options.checksum.Sum(nil), options.expected) {
+		return file, fmt.Errorf("file %s: %w", fileID, ErrChecksumMismatch)
+	}
+
+	return file, nil
+}
+
+// OpenFile opens the file for reading, the reader must be closed by the caller.
+// The reader fails with io.ErrUnexpectedEOF if the file turns out to be shorter than reported by API,
+// ErrSizeMismatch if it is longer and ErrFileTooLarge if the size limit is exceeded.
+func (c *Client) OpenFile(ctx context.Context, fileID string, opts ...DownloadOption) (io.ReadCloser, *File, error) {
+	options := c.newDownloadOptions(opts)
+
+	file, err := c.GetFileInfoWithContext(ctx, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := options.checkSize(file); err != nil {
+		return nil, file, err
+	}
+
+	resp, err := c.openDownload(ctx, file, options.offset)
+	if err != nil {
+		return nil, file, fmt.Errorf("cannot open file %s: %w", fileID, err)
+	}
+
+	reader := &downloadReader{
+		body:    resp.Body,
+		path:    resp.Request.URL.Path,
+		read:    options.offset,
+		maxSize: options.maxSize,
+	}
+	if file.Size > 0 {
+		reader.size = int64(file.Size)
+	}
+
+	return reader, file, nil
+}
+
+func (o *downloadOptions) checkSize(file *File) error {
+	if o.maxSize > 0 && file.Size > uint64(o.maxSize) {
+		return fmt.Errorf("file %s has size %d bytes, limit is %d bytes: %w", file.ID, file.Size, o.maxSize, ErrFileTooLarge)
+	}
+	return nil
+}
+
+// openDownload requests the file content starting from offset.
+// The request passes through the client's interceptors and rate limiter like API calls.
+func (c *Client) openDownload(ctx context.Context, file *File, offset int64) (*http.Response, error) {
+	call := &APICall{
+		Path:   DownloadPath,
+		Params: url.Values{"fileId": {file.ID}, "offset": {strconv.FormatInt(offset, 10)}},
+	}
+	ctx, span := c.traceCall(ctx, call)
+
+	start := time.Now()
+	result, err := c.chain(func(ctx context.Context, call *APICall) (*APIResult, error) {
+		resp, err := c.limitDownload(ctx, call, file, offset)
+		if err != nil {
+			return nil, err
+		}
+		return &APIResult{download: resp}, nil
+	})(ctx, call)
+	if err == nil && (result == nil || result.download == nil) {
+		err = fmt.Errorf("download of file %s was not performed by interceptors", file.ID)
+	}
+	c.observeCall(DownloadPath, start, err)
+	endCall(span, call, err)
+
+	if err != nil {
+		if result != nil && result.download != nil {
+			c.closeBody(result.download)
+		}
+		return nil, err
+	}
+	return result.download, nil
+}
+
+// limitDownload waits for the rate limiter and makes the request
+func (c *Client) limitDownload(ctx context.Context, call *APICall, file *File, offset int64) (*http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, call.Path, call.Params); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.requestDownload(ctx, file, offset)
+
+	var apiErr *APIError
+	if c.limiter != nil && errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		c.limiter.Backoff(call.Path, call.Params, apiErr.RetryAfter)
+	}
+	return resp, err
+}
+
+// requestDownload makes a single request of the file content
+func (c *Client) requestDownload(ctx context.Context, file *File, offset int64) (*http.Response, error) {
+	fileURL, err := url.Parse(file.URL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse file url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot init http request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	c.logger.Log(LogLevelDebug, "downloading file", LogFields{
+		"file_id": file.ID,
+		"offset":  offset,
+	})
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, &TransportError{Path: fileURL.Path, Err: err}
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		c.closeBody(resp)
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Path:       fileURL.Path,
+			RequestID:  resp.Header.Get("X-Request-Id"),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	// the partial content must start where the downloaded data ends, otherwise it would be corrupted
+	if resp.StatusCode == http.StatusPartialContent {
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+			c.closeBody(resp)
+			return nil, fmt.Errorf("requested offset %d, got content range %q: %w",
+				offset, resp.Header.Get("Content-Range"), ErrRangeMismatch)
+		}
+	}
+
+	// server ignored the Range header and sent the whole file, skip the data we already have
+	if offset > 0 && resp.StatusCode == http.StatusOK {
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			c.closeBody(resp)
+			return nil, &TransportError{Path: fileURL.Path, Err: err}
+		}
+	}
+
+	return resp, nil
+}
+
+// contentRangeStart returns the first byte of Content-Range header, e.g. 10 for "bytes 10-35/36"
+func contentRangeStart(header string) (int64, bool) {
+	rest, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	return start, err == nil
+}
+
+// downloadPart copies the file content from offset to w and returns the number of written bytes,
+// it fails with ErrFileTooLarge if the file exceeds maxSize
+func (c *Client) downloadPart(ctx context.Context, file *File, offset, maxSize int64, w io.Writer) (int64, error) {
+	resp, err := c.openDownload(ctx, file, offset)
+	if err != nil {
+		return 0, err
+	}
+	defer c.closeBody(resp)
+
+	reader := &downloadReader{
+		body:    resp.Body,
+		path:    resp.Request.URL.Path,
+		read:    offset,
+		maxSize: maxSize,
+	}
+	if file.Size > 0 {
+		reader.size = int64(file.Size)
+	}
+
+	return io.Copy(w, reader)
+}
+
+func (c *Client) closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		c.logger.Log(LogLevelError, "cannot close body", LogFields{
+			"err": err,
+		})
+	}
+}
+
+// downloadReader verifies the length of the downloaded data.
+// Interrupted reads are reported as TransportError, so they can be resumed.
+type downloadReader struct {
+	body    io.ReadCloser
+	path    string
+	read    int64
+	size    int64
+	maxSize int64
+}
+
+func (r *downloadReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.read += int64(n)
+
+	if r.maxSize > 0 && r.read > r.maxSize {
+		return n, fmt.Errorf("file exceeds limit of %d bytes: %w", r.maxSize, ErrFileTooLarge)
+	}
+	if r.size > 0 && r.read > r.size {
+		return n, fmt.Errorf("received more than %d bytes: %w", r.size, ErrSizeMismatch)
+	}
+	if err == io.EOF && r.size > 0 && r.read < r.size {
+		err = fmt.Errorf("received %d bytes of %d: %w", r.read, r.size, io.ErrUnexpectedEOF)
+	}
+	if err != nil && err != io.EOF {
+		return n, &TransportError{Path: r.path, Err: err}
+	}
+
+	return n, err
+}
+
+func (r *downloadReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,262 @@
+package botgolang
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const downloadTestContent = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// newDownloadTestClient serves the file info and the file content.
+// The first requests of the content, up to interruptions count, are cut in the middle.
+func newDownloadTestClient(t *testing.T, size int, interruptions int32) (*Client, *int32) {
+	t.Helper()
+
+	var requests int32
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/files/getInfo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"ok":true,"fileId":%q,"size":%d,"filename":"file.txt","url":"%s/download"}`,
+			r.FormValue("fileId"), size, server.URL)
+	})
+	mux.HandleFunc("/download", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= interruptions {
+			w.Header().Set("Content-Length", strconv.Itoa(len(downloadTestContent)))
+			_, _ = w.Write([]byte(downloadTestContent[:10]))
+			return
+		}
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader(downloadTestContent))
+	})
+
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	return client, &requests
+}
+
+func TestClient_DownloadFile(t *testing.T) {
+	client, _ := newDownloadTestClient(t, len(downloadTestContent), 0)
+
+	buffer := &bytes.Buffer{}
+	file, err := client.DownloadFile(context.Background(), "file_id", buffer)
+
+	require.NoError(t, err)
+	assert.Equal(t, "file_id", file.ID)
+	assert.Equal(t, downloadTestContent, buffer.String())
+}
+
+func TestClient_DownloadFile_ResumesInterrupted(t *testing.T) {
+	client, requests := newDownloadTestClient(t, len(downloadTestContent), 1)
+	client.retryPolicy = testRetryPolicy()
+
+	buffer := &bytes.Buffer{}
+	_, err := client.DownloadFile(context.Background(), "file_id", buffer)
+
+	require.NoError(t, err)
+	assert.Equal(t, downloadTestContent, buffer.String())
+	assert.EqualValues(t, 2, atomic.LoadInt32(requests))
+}
+
+func TestClient_DownloadFile_InterruptedWithoutRetries(t *testing.T) {
+	client, _ := newDownloadTestClient(t, len(downloadTestContent), 1)
+
+	_, err := client.DownloadFile(context.Background(), "file_id", io.Discard)
+
+	require.ErrorIs(t, err, ErrTransport)
+}
+
+func TestClient_DownloadFile_Offset(t *testing.T) {
+	client, _ := newDownloadTestClient(t, len(downloadTestContent), 0)
+
+	buffer := &bytes.Buffer{}
+	_, err := client.DownloadFile(context.Background(), "file_id", buffer, DownloadOffset(30))
+
+	require.NoError(t, err)
+	assert.Equal(t, downloadTestContent[30:], buffer.String())
+}
+
+func TestClient_DownloadFile_Verification(t *testing.T) {
+	sum := sha256.Sum256([]byte(downloadTestContent))
+
+	tests := []struct {
+		name     string
+		size     int
+		opts     []DownloadOption
+		expected error
+	}{
+		{
+			name: "valid_checksum",
+			size: len(downloadTestContent),
+			opts: []DownloadOption{DownloadChecksum(sha256.New(), sum[:])},
+		},
+		{
+			name:     "invalid_checksum",
+			size:     len(downloadTestContent),
+			opts:     []DownloadOption{DownloadChecksum(sha256.New(), []byte("invalid"))},
+			expected: ErrChecksumMismatch,
+		},
+		{
+			name:     "size_limit",
+			size:     len(downloadTestContent),
+			opts:     []DownloadOption{DownloadMaxSize(10)},
+			expected: ErrFileTooLarge,
+		},
+		{
+			name:     "size_limit_unknown_size",
+			opts:     []DownloadOption{DownloadMaxSize(10)},
+			expected: ErrFileTooLarge,
+		},
+		{
+			name:     "longer_than_reported",
+			size:     10,
+			expected: ErrSizeMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newDownloadTestClient(t, tt.size, 0)
+
+			_, err := client.DownloadFile(context.Background(), "file_id", io.Discard, tt.opts...)
+
+			if tt.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClient_OpenFile(t *testing.T) {
+	client, _ := newDownloadTestClient(t, len(downloadTestContent), 0)
+
+	reader, file, err := client.OpenFile(context.Background(), "file_id")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, reader.Close())
+	})
+
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, downloadTestContent, string(content))
+	assert.Equal(t, "file.txt", file.Name)
+}
+
+func TestClient_OpenFile_Truncated(t *testing.T) {
+	client, _ := newDownloadTestClient(t, len(downloadTestContent), 1)
+
+	reader, _, err := client.OpenFile(context.Background(), "file_id")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, reader.Close())
+	})
+
+	_, err = io.ReadAll(reader)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+type downloadTestLimiter struct {
+	paths []string
+}
+
+func (l *downloadTestLimiter) Wait(ctx context.Context, path string, params url.Values) error {
+	l.paths = append(l.paths, path)
+	return nil
+}
+
+func (l *downloadTestLimiter) Backoff(path string, params url.Values, delay time.Duration) {}
+
+func TestClient_DownloadFile_Pipeline(t *testing.T) {
+	client, _ := newDownloadTestClient(t, len(downloadTestContent), 1)
+	client.retryPolicy = testRetryPolicy()
+	limiter := &downloadTestLimiter{}
+	client.limiter = limiter
+	metrics := newTestMetrics()
+	client.metrics = metrics
+
+	var offsets []string
+	client.interceptors = []Interceptor{func(ctx context.Context, call *APICall, next Invoker) (*APIResult, error) {
+		if call.Path == DownloadPath {
+			assert.Equal(t, "file_id", call.Params.Get("fileId"))
+			offsets = append(offsets, call.Params.Get("offset"))
+		}
+		return next(ctx, call)
+	}}
+
+	buffer := &bytes.Buffer{}
+	_, err := client.DownloadFile(context.Background(), "file_id", buffer)
+
+	require.NoError(t, err)
+	assert.Equal(t, downloadTestContent, buffer.String())
+	assert.Equal(t, []string{"0", "10"}, offsets)
+	assert.Equal(t, []string{"/files/getInfo", DownloadPath, DownloadPath}, limiter.paths)
+	assert.Equal(t, 2.0, metrics.counters[MetricAPIRequests+" method="+DownloadPath+" result=ok"])
+	assert.Equal(t, 1.0, metrics.counters[MetricAPIRetries+" method="+DownloadPath])
+
+	// interceptors can refuse the download
+	client.interceptors = []Interceptor{func(ctx context.Context, call *APICall, next Invoker) (*APIResult, error) {
+		return &APIResult{}, nil
+	}}
+	_, _, err = client.OpenFile(context.Background(), "file_id")
+	require.Error(t, err)
+}
+
+func TestClient_DownloadFile_RangeMismatch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/files/getInfo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"ok":true,"fileId":"file_id","size":%d,"url":"%s/download"}`, len(downloadTestContent), server.URL)
+	})
+	mux.HandleFunc("/download", func(w http.ResponseWriter, r *http.Request) {
+		// the range is ignored, but the whole file is sent as partial content
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(downloadTestContent)-1, len(downloadTestContent)))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte(downloadTestContent))
+	})
+
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	client.retryPolicy = testRetryPolicy()
+
+	buffer := &bytes.Buffer{}
+	_, err := client.DownloadFile(context.Background(), "file_id", buffer, DownloadOffset(30))
+
+	require.ErrorIs(t, err, ErrRangeMismatch)
+	assert.Empty(t, buffer.String())
+}
+
+func TestContentRangeStart(t *testing.T) {
+	tests := []struct {
+		header string
+		start  int64
+		ok     bool
+	}{
+		{header: "bytes 10-35/36", start: 10, ok: true},
+		{header: "bytes 0-35/*", start: 0, ok: true},
+		{header: "bytes */36"},
+		{header: ""},
+	}
+
+	for _, tt := range tests {
+		start, ok := contentRangeStart(tt.header)
+		assert.Equal(t, tt.ok, ok, tt.header)
+		assert.Equal(t, tt.start, start, tt.header)
+	}
+}
@@ -0,0 +1,144 @@
+package botgolang
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors which can be checked with errors.Is
+var (
+	// ErrChatNotFound means that the requested chat doesn't exist or the bot has no access to it
+	ErrChatNotFound = errors.New("chat not found")
+
+	// ErrNotAdmin means that the bot has no admin rights required by the method
+	ErrNotAdmin = errors.New("bot is not admin")
+
+	// ErrRateLimited means that API has rejected the request because of too many requests
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrUnauthorized means that the bot token is missing or invalid
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrTransport means that the request failed before API responded
+	ErrTransport = errors.New("transport error")
+
+	// ErrDecode means that the API response cannot be decoded
+	ErrDecode = errors.New("cannot decode response")
+
+	// ErrFileTooLarge means that the file exceeds the configured size limit
+	ErrFileTooLarge = errors.New("file is too large")
+
+	// ErrSizeMismatch means that the size of the downloaded file differs from the size reported by API
+	ErrSizeMismatch = errors.New("file size mismatch")
+
+	// ErrRangeMismatch means that the resumed download received content from a different offset than requested
+	ErrRangeMismatch = errors.New("content range mismatch")
+
+	// ErrChecksumMismatch means that the downloaded file doesn't match the expected checksum
+	ErrChecksumMismatch = errors.New("file checksum mismatch")
+
+	// ErrInvalidFormat means that ranges of the message format are outside of the text or overlap illegally
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+// apiErrorMarkers are lowercase substrings of Response.Description which identify sentinel errors
+var apiErrorMarkers = map[error][]string{
+	ErrChatNotFound: {"chat not found", "chat is not found", "no such chat"},
+	ErrNotAdmin:     {"not admin", "permission denied", "not enough rights"},
+	ErrRateLimited:  {"rate limit", "too many requests"},
+	ErrUnauthorized: {"invalid token", "parameter 'token'"},
+}
+
+// APIError is returned when API responds with a non-200 HTTP status or with "ok": false
+type APIError struct {
+	// HTTP status code of the response
+	StatusCode int
+
+	// Description of the error from API response, can be empty for non-200 statuses without JSON body
+	Description string
+
+	// Path of the API method, e.g. /messages/sendText
+	Path string
+
+	// RequestID of the request, taken from request-id parameter or X-Request-Id response header
+	RequestID string
+
+	// RetryAfter is the delay requested by API in Retry-After header
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("error status from API: %s", e.Description)
+	}
+	return fmt.Sprintf("error status from API: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is makes APIError comparable with the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		if e.StatusCode == http.StatusTooManyRequests {
+			return true
+		}
+	case ErrUnauthorized:
+		if e.StatusCode == http.StatusUnauthorized {
+			return true
+		}
+	case ErrNotAdmin:
+		if e.StatusCode == http.StatusForbidden {
+			return true
+		}
+	}
+
+	description := strings.ToLower(e.Description)
+	for _, marker := range apiErrorMarkers[target] {
+		if strings.Contains(description, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// TransportError is returned when the request failed before API responded
+type TransportError struct {
+	// Path of the API method
+	Path string
+
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("cannot make request to bot api: %s", e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TransportError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// DecodeError is returned when the API response cannot be decoded
+type DecodeError struct {
+	// Path of the API method
+	Path string
+
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
@@ -0,0 +1,145 @@
+package botgolang
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *APIError
+		target   error
+		expected bool
+	}{
+		{
+			name:     "chat_not_found",
+			err:      &APIError{StatusCode: http.StatusOK, Description: "Chat not found"},
+			target:   ErrChatNotFound,
+			expected: true,
+		},
+		{
+			name:     "not_admin",
+			err:      &APIError{StatusCode: http.StatusOK, Description: "Permission denied"},
+			target:   ErrNotAdmin,
+			expected: true,
+		},
+		{
+			name:     "rate_limited_by_status",
+			err:      &APIError{StatusCode: http.StatusTooManyRequests},
+			target:   ErrRateLimited,
+			expected: true,
+		},
+		{
+			name:     "unauthorized_by_description",
+			err:      &APIError{StatusCode: http.StatusOK, Description: "Invalid token"},
+			target:   ErrUnauthorized,
+			expected: true,
+		},
+		{
+			name:     "other_description",
+			err:      &APIError{StatusCode: http.StatusOK, Description: "Chat not found"},
+			target:   ErrNotAdmin,
+			expected: false,
+		},
+		{
+			name:     "not_transport",
+			err:      &APIError{StatusCode: http.StatusBadGateway},
+			target:   ErrTransport,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+func TestClient_Do_APIError(t *testing.T) {
+	client := NewApiMockClient(t)
+	client.token = ""
+
+	_, err := client.Do("/messages/sendText", url.Values{"request-id": {"req-1"}}, nil)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusOK, apiErr.StatusCode)
+	assert.Equal(t, "Missing required parameter 'token'", apiErr.Description)
+	assert.Equal(t, "/messages/sendText", apiErr.Path)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestClient_Do_StatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "server-request")
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	err := client.SendChatActions("chat", TypingAction)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, "server-request", apiErr.RequestID)
+	assert.Equal(t, 2*time.Second, apiErr.RetryAfter)
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestClient_Do_StatusErrorWithDescription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"chat not found"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	_, err := client.GetChatInfo("chat")
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "chat not found", apiErr.Description)
+	assert.ErrorIs(t, err, ErrChatNotFound)
+}
+
+func TestClient_Do_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	_, err := client.GetChatInfo("chat")
+
+	var transportErr *TransportError
+	require.ErrorAs(t, err, &transportErr)
+	assert.Equal(t, "/chats/getInfo", transportErr.Path)
+	assert.ErrorIs(t, err, ErrTransport)
+}
+
+func TestClient_Do_DecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`not a json`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	_, err := client.GetFileInfo("file")
+
+	var decodeErr *DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, "/files/getInfo", decodeErr.Path)
+	assert.ErrorIs(t, err, ErrDecode)
+}
@@ -0,0 +1,196 @@
+package botgolang
+
+//go:generate easyjson -all events.go
+
+import (
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// knownEventTypes are event types supported by the library,
+// payloads of the types marked false have typed accessors and their raw payload is kept
+var knownEventTypes = map[EventType]bool{
+	NEW_MESSAGE:       true,
+	EDITED_MESSAGE:    true,
+	DELETED_MESSAGE:   true,
+	PINNED_MESSAGE:    true,
+	UNPINNED_MESSAGE:  true,
+	NEW_CHAT_MEMBERS:  true,
+	LEFT_CHAT_MEMBERS: true,
+	CALLBACK_QUERY:    true,
+	CHANGED_CHAT_INFO: false,
+	NEW_THREAD:        false,
+	CHANGED_THREAD:    false,
+}
+
+// ChatInfoChangedPayload is the payload of changedChatInfo event.
+// Only changed fields of the chat info are filled.
+type ChatInfoChangedPayload struct {
+	// Chat with the changed info
+	Chat Chat `json:"chat"`
+
+	// User who changed the info
+	From Contact `json:"from"`
+
+	// New title of the chat
+	Title string `json:"title"`
+
+	// New description of the chat
+	About string `json:"about"`
+
+	// New rules of the chat
+	Rules string `json:"rules"`
+
+	// Timestamp of the event
+	Timestamp int `json:"timestamp"`
+}
+
+// ThreadPayload is the payload of newThread and changedThread events
+type ThreadPayload struct {
+	// Id of the thread
+	ThreadID string `json:"threadId"`
+
+	// Chat of the thread
+	Chat Chat `json:"chat"`
+
+	// User who created or changed the thread
+	From Contact `json:"from"`
+
+	// Message which the thread belongs to
+	ParentMessage *ParentMessage `json:"parent_topic"`
+
+	// Timestamp of the event
+	Timestamp int `json:"timestamp"`
+}
+
+// UnknownEvent is an event of the type which the library doesn't know
+type UnknownEvent struct {
+	// Type of the event
+	Type EventType
+
+	// Payload of the event as received from API
+	Payload []byte
+}
+
+// Known reports whether the library knows the type of the event
+func (e *Event) Known() bool {
+	_, ok := knownEventTypes[e.Type]
+	return ok
+}
+
+// Unknown returns the event with raw payload if the library doesn't know its type, otherwise nil.
+// Use it to handle events added to API after the release of the library.
+func (e *Event) Unknown() *UnknownEvent {
+	if e.Known() {
+		return nil
+	}
+	return &UnknownEvent{Type: e.Type, Payload: e.raw}
+}
+
+// ChatInfoChange returns the payload of changedChatInfo event or nil for other events
+func (e *Event) ChatInfoChange() (*ChatInfoChangedPayload, error) {
+	if e.Type != CHANGED_CHAT_INFO {
+		return nil, nil
+	}
+
+	payload := &ChatInfoChangedPayload{}
+	if err := e.decodePayload(payload); err != nil {
+		return nil, err
+	}
+	payload.Chat.client = e.client
+	return payload, nil
+}
+
+// Thread returns the payload of newThread and changedThread events or nil for other events
+func (e *Event) Thread() (*ThreadPayload, error) {
+	if e.Type != NEW_THREAD && e.Type != CHANGED_THREAD {
+		return nil, nil
+	}
+
+	payload := &ThreadPayload{}
+	if err := e.decodePayload(payload); err != nil {
+		return nil, err
+	}
+	payload.Chat.client = e.client
+	return payload, nil
+}
+
+func (e *Event) decodePayload(payload easyjson.Unmarshaler) error {
+	if e.raw == nil {
+		return nil
+	}
+	return easyjson.Unmarshal(e.raw, payload)
+}
+
+// UnmarshalEasyJSON decodes the event, the raw payload is kept for typed payloads and unknown events
+func (e *Event) UnmarshalEasyJSON(in *jlexer.Lexer) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "eventId":
+			e.EventID = in.Int()
+		case "type":
+			e.Type = EventType(in.String())
+		case "payload":
+			e.raw = append([]byte(nil), in.Raw()...)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+
+	// payloads of unknown events may have fields of other types, they are not decoded
+	if e.raw != nil && e.Known() && in.Ok() {
+		in.AddError(easyjson.Unmarshal(e.raw, &e.Payload))
+	}
+	if knownEventTypes[e.Type] {
+		e.raw = nil
+	}
+
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+
+// MarshalEasyJSON encodes the event, the raw payload is written if it is kept
+func (e Event) MarshalEasyJSON(out *jwriter.Writer) {
+	out.RawString(`{"eventId":`)
+	out.Int(e.EventID)
+	out.RawString(`,"type":`)
+	out.String(string(e.Type))
+	out.RawString(`,"payload":`)
+	if e.raw != nil {
+		out.Raw(e.raw, nil)
+	} else {
+		e.Payload.MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (e *Event) UnmarshalJSON(data []byte) error {
+	return easyjson.Unmarshal(data, e)
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (e Event) MarshalJSON() ([]byte, error) {
+	return easyjson.Marshal(e)
+}
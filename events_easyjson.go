@@ -0,0 +1,304 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package botgolang
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson692db02bDecodeGithubComMailRuImBotGolang(in *jlexer.Lexer, out *UnknownEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Type":
+			out.Type = EventType(in.String())
+		case "Payload":
+			if in.IsNull() {
+				in.Skip()
+				out.Payload = nil
+			} else {
+				out.Payload = in.Bytes()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson692db02bEncodeGithubComMailRuImBotGolang(out *jwriter.Writer, in UnknownEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"Payload\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Payload)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UnknownEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson692db02bEncodeGithubComMailRuImBotGolang(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UnknownEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson692db02bEncodeGithubComMailRuImBotGolang(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UnknownEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson692db02bDecodeGithubComMailRuImBotGolang(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UnknownEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson692db02bDecodeGithubComMailRuImBotGolang(l, v)
+}
+func easyjson692db02bDecodeGithubComMailRuImBotGolang1(in *jlexer.Lexer, out *ThreadPayload) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "threadId":
+			out.ThreadID = string(in.String())
+		case "chat":
+			(out.Chat).UnmarshalEasyJSON(in)
+		case "from":
+			(out.From).UnmarshalEasyJSON(in)
+		case "parent_topic":
+			if in.IsNull() {
+				in.Skip()
+				out.ParentMessage = nil
+			} else {
+				if out.ParentMessage == nil {
+					out.ParentMessage = new(ParentMessage)
+				}
+				(*out.ParentMessage).UnmarshalEasyJSON(in)
+			}
+		case "timestamp":
+			out.Timestamp = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson692db02bEncodeGithubComMailRuImBotGolang1(out *jwriter.Writer, in ThreadPayload) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"threadId\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ThreadID))
+	}
+	{
+		const prefix string = ",\"chat\":"
+		out.RawString(prefix)
+		(in.Chat).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		(in.From).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"parent_topic\":"
+		out.RawString(prefix)
+		if in.ParentMessage == nil {
+			out.RawString("null")
+		} else {
+			(*in.ParentMessage).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		out.Int(int(in.Timestamp))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadPayload) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson692db02bEncodeGithubComMailRuImBotGolang1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadPayload) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson692db02bEncodeGithubComMailRuImBotGolang1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadPayload) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson692db02bDecodeGithubComMailRuImBotGolang1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadPayload) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson692db02bDecodeGithubComMailRuImBotGolang1(l, v)
+}
+func easyjson692db02bDecodeGithubComMailRuImBotGolang2(in *jlexer.Lexer, out *ChatInfoChangedPayload) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "chat":
+			(out.Chat).UnmarshalEasyJSON(in)
+		case "from":
+			(out.From).UnmarshalEasyJSON(in)
+		case "title":
+			out.Title = string(in.String())
+		case "about":
+			out.About = string(in.String())
+		case "rules":
+			out.Rules = string(in.String())
+		case "timestamp":
+			out.Timestamp = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson692db02bEncodeGithubComMailRuImBotGolang2(out *jwriter.Writer, in ChatInfoChangedPayload) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"chat\":"
+		out.RawString(prefix[1:])
+		(in.Chat).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		(in.From).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"about\":"
+		out.RawString(prefix)
+		out.String(string(in.About))
+	}
+	{
+		const prefix string = ",\"rules\":"
+		out.RawString(prefix)
+		out.String(string(in.Rules))
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		out.Int(int(in.Timestamp))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatInfoChangedPayload) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson692db02bEncodeGithubComMailRuImBotGolang2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatInfoChangedPayload) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson692db02bEncodeGithubComMailRuImBotGolang2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatInfoChangedPayload) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson692db02bDecodeGithubComMailRuImBotGolang2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatInfoChangedPayload) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson692db02bDecodeGithubComMailRuImBotGolang2(l, v)
+}
@@ -0,0 +1,72 @@
+package botgolang
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const newEventsResponse = `{"ok": true, "events": [
+	{"eventId": 1, "type": "changedChatInfo", "payload": {
+		"chat": {"chatId": "group@chat.agent", "type": "group"},
+		"from": {"userId": "admin", "firstName": "Admin"},
+		"title": "New title",
+		"timestamp": 1700000000
+	}},
+	{"eventId": 2, "type": "newThread", "payload": {
+		"threadId": "thread",
+		"chat": {"chatId": "group@chat.agent"},
+		"parent_topic": {"chatId": "group@chat.agent", "messageId": 42, "type": "thread"}
+	}},
+	{"eventId": 3, "type": "futureEvent", "payload": {"chat": "not an object", "value": [1, 2]}},
+	{"eventId": 4, "type": "newMessage", "payload": {"msgId": "1", "chat": {"chatId": "chat"}, "text": "hi"}}
+]}`
+
+func TestClient_GetEvents_NewTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(newEventsResponse))
+	}))
+	defer server.Close()
+	client := NewCustomClientWithLogger(http.DefaultClient, server.URL, "test_token", nil)
+
+	events, err := client.GetEvents(0, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	info, err := events[0].ChatInfoChange()
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "group@chat.agent", info.Chat.ID)
+	assert.Equal(t, "admin", info.From.ID)
+	assert.Equal(t, "New title", info.Title)
+	assert.Empty(t, info.About)
+	assert.Equal(t, "group@chat.agent", events[0].Payload.Chat.ID)
+	assert.Nil(t, events[0].Unknown())
+
+	thread, err := events[1].Thread()
+	require.NoError(t, err)
+	require.NotNil(t, thread)
+	assert.Equal(t, "thread", thread.ThreadID)
+	assert.Equal(t, int64(42), thread.ParentMessage.MsgID)
+	info, err = events[1].ChatInfoChange()
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	unknown := events[2].Unknown()
+	require.NotNil(t, unknown)
+	assert.False(t, events[2].Known())
+	assert.Equal(t, EventType("futureEvent"), unknown.Type)
+	assert.JSONEq(t, `{"chat": "not an object", "value": [1, 2]}`, string(unknown.Payload))
+
+	assert.True(t, events[3].Known())
+	assert.Equal(t, "hi", events[3].Payload.Text)
+	assert.Nil(t, events[3].Unknown())
+
+	data, err := json.Marshal(events[2])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"eventId": 3, "type": "futureEvent", "payload": {"chat": "not an object", "value": [1, 2]}}`, string(data))
+}
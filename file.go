@@ -45,3 +45,11 @@ func NewUploadFileFromReader(name string, r io.Reader) UploadFile {
 		name:   name,
 	}
 }
+
+// uploadSource returns the reader which holds the file data
+func uploadSource(file UploadFile) io.Reader {
+	if u, ok := file.(uploadReader); ok {
+		return u.Reader
+	}
+	return file
+}
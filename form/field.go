@@ -0,0 +1,190 @@
+package form
+
+import (
+	"strconv"
+	"strings"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	choiceField
+	yesNoField
+	numberField
+	fileField
+)
+
+// Field is a question of the form, the answer is stored under the name of the field
+type Field struct {
+	name       string
+	prompt     string
+	kind       fieldKind
+	options    []string
+	min, max   *float64
+	validators []func(value string) error
+}
+
+// Text asks for a free text
+func Text(name, prompt string) *Field {
+	return &Field{name: name, prompt: prompt, kind: textField}
+}
+
+// Choice asks to choose one of the options with buttons, the option can also be typed.
+// The answer is the text of the option.
+func Choice(name, prompt string, options ...string) *Field {
+	return &Field{name: name, prompt: prompt, kind: choiceField, options: options}
+}
+
+// YesNo asks a question with Yes and No buttons, the answer is true or false
+func YesNo(name, prompt string) *Field {
+	return &Field{name: name, prompt: prompt, kind: yesNoField}
+}
+
+// Number asks for a number
+func Number(name, prompt string) *Field {
+	return &Field{name: name, prompt: prompt, kind: numberField}
+}
+
+// File asks to send a file, the answer is the id of the file
+func File(name, prompt string) *Field {
+	return &Field{name: name, prompt: prompt, kind: fileField}
+}
+
+// Range limits the number field to values from min to max inclusive
+func (f *Field) Range(min, max float64) *Field {
+	f.min, f.max = &min, &max
+	return f
+}
+
+// Validate adds a check of the answer, the error text is sent to the user and the question is asked again
+func (f *Field) Validate(validator func(value string) error) *Field {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// keyboard returns the buttons of the field, back button is added if the user can go back
+func (f *Field) keyboard(form string, back bool, texts Texts) *botgolang.Keyboard {
+	keyboard := botgolang.NewKeyboard()
+
+	switch f.kind {
+	case choiceField:
+		for i, option := range f.options {
+			keyboard.AddRow(botgolang.NewCallbackButton(option, callbackData(form, f.name, strconv.Itoa(i))))
+		}
+	case yesNoField:
+		keyboard.AddRow(
+			botgolang.NewCallbackButton(texts.Yes, callbackData(form, f.name, "yes")),
+			botgolang.NewCallbackButton(texts.No, callbackData(form, f.name, "no")),
+		)
+	}
+	if back {
+		keyboard.AddRow(botgolang.NewCallbackButton(texts.Back, callbackData(form, f.name, backAnswer)))
+	}
+
+	if keyboard.RowsCount() == 0 {
+		return nil
+	}
+	return &keyboard
+}
+
+// parse returns the answer to the field from the event or the problem with it to send to the user
+func (f *Field) parse(form string, event botgolang.Event, texts Texts) (value, problem string) {
+	text := strings.TrimSpace(event.Payload.Text)
+	data, isCallback := "", event.Type == botgolang.CALLBACK_QUERY
+	if isCallback {
+		var ok bool
+		if data, ok = parseCallbackData(form, f.name, event.Payload.CallbackData); !ok {
+			return "", texts.WrongButton
+		}
+	}
+
+	switch f.kind {
+	case textField:
+		if isCallback || text == "" {
+			return "", texts.SendText
+		}
+		value = text
+	case choiceField:
+		index := -1
+		if isCallback {
+			index, _ = strconv.Atoi(data)
+		} else {
+			for i, option := range f.options {
+				if strings.EqualFold(option, text) {
+					index = i
+				}
+			}
+		}
+		if index < 0 || index >= len(f.options) {
+			return "", texts.ChooseOption
+		}
+		value = f.options[index]
+	case yesNoField:
+		if !isCallback {
+			// typed answers are the button labels or the english shortcuts
+			switch {
+			case strings.EqualFold(text, texts.Yes), strings.EqualFold(text, "yes"), strings.EqualFold(text, "y"):
+				data = "yes"
+			case strings.EqualFold(text, texts.No), strings.EqualFold(text, "no"), strings.EqualFold(text, "n"):
+				data = "no"
+			}
+		}
+		switch data {
+		case "yes":
+			value = "true"
+		case "no":
+			value = "false"
+		default:
+			return "", texts.AnswerYesNo
+		}
+	case numberField:
+		number, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+		if isCallback || err != nil {
+			return "", texts.SendNumber
+		}
+		if (f.min != nil && number < *f.min) || (f.max != nil && number > *f.max) {
+			return "", texts.numberRange(*f.min, *f.max)
+		}
+		value = strconv.FormatFloat(number, 'f', -1, 64)
+	case fileField:
+		for _, part := range event.Payload.Parts {
+			if part.Type == botgolang.FILE {
+				value = part.Payload.FileID
+				break
+			}
+		}
+		if isCallback || value == "" {
+			return "", texts.SendFile
+		}
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(value); err != nil {
+			return "", err.Error()
+		}
+	}
+	return value, ""
+}
+
+const backAnswer = "back"
+
+// callbackData returns data of the button answering the field, buttons of other fields are not accepted
+func callbackData(form, field, answer string) string {
+	return "form/" + form + "/" + field + "/" + answer
+}
+
+func parseCallbackData(form, field, data string) (string, bool) {
+	return strings.CutPrefix(data, "form/"+form+"/"+field+"/")
+}
+
+// isBack reports whether the event asks to return to the previous field
+func (f *Field) isBack(form string, event botgolang.Event) bool {
+	if event.Type == botgolang.CALLBACK_QUERY {
+		data, ok := parseCallbackData(form, f.name, event.Payload.CallbackData)
+		return ok && data == backAnswer
+	}
+	return strings.EqualFold(strings.TrimSpace(event.Payload.Text), BackCommand)
+}
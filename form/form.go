@@ -0,0 +1,161 @@
+// Package form drives the user through a sequence of questions and delivers the answers at the end.
+// Each question is a state of the fsm conversation, so forms can be mixed with other conversations of the machine.
+package form
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+	"github.com/mail-ru-im/bot-golang/fsm"
+)
+
+// BackCommand returns to the previous question when it is sent as a text
+const BackCommand = "/back"
+
+// SubmitHandler receives the answers when all questions are answered
+type SubmitHandler func(ctx context.Context, result *Result) error
+
+// Form is a sequence of questions
+type Form struct {
+	name     string
+	fields   []*Field
+	machine  *fsm.Machine
+	onSubmit SubmitHandler
+	texts    Texts
+}
+
+// New returns new form and registers the states of its fields in the machine.
+// The name must be unique among the forms of the machine, it is used in states and data of buttons.
+func New(machine *fsm.Machine, name string, fields ...*Field) *Form {
+	f := &Form{name: name, fields: fields, machine: machine, texts: DefaultTexts()}
+
+	names := make(map[string]bool, len(fields))
+	for i, field := range fields {
+		if names[field.name] {
+			panic(fmt.Sprintf("form %q: duplicate field %q", name, field.name))
+		}
+		names[field.name] = true
+
+		var transitions []fsm.State
+		if i > 0 {
+			transitions = append(transitions, f.state(i-1))
+		}
+		if i < len(fields)-1 {
+			transitions = append(transitions, f.state(i+1))
+		}
+		machine.State(f.state(i), f.handler(i), transitions...)
+	}
+
+	return f
+}
+
+// OnSubmit sets the handler of filled forms
+func (f *Form) OnSubmit(handler SubmitHandler) *Form {
+	f.onSubmit = handler
+	return f
+}
+
+// WithTexts sets the labels of buttons and the problems sent to the user, empty texts keep their defaults
+func (f *Form) WithTexts(texts Texts) *Form {
+	f.texts = texts.withDefaults()
+	return f
+}
+
+// Start begins the form in the conversation of the event and asks the first question.
+// It must not be called from state handlers of the machine.
+func (f *Form) Start(ctx context.Context, event botgolang.Event) error {
+	if len(f.fields) == 0 {
+		return errors.New("form has no fields")
+	}
+	if err := f.machine.Start(ctx, event, f.state(0)); err != nil {
+		return fmt.Errorf("cannot start form: %w", err)
+	}
+	return f.ask(ctx, eventMessage(event), 0, "")
+}
+
+func (f *Form) state(index int) fsm.State {
+	return fsm.State(f.name + "/" + f.fields[index].name)
+}
+
+func (f *Form) handler(index int) fsm.Handler {
+	field := f.fields[index]
+
+	return func(ctx context.Context, conv *fsm.Conversation) (err error) {
+		if conv.Event.Type == botgolang.CALLBACK_QUERY {
+			response := conv.Event.Payload.CallbackQuery()
+			defer func() {
+				if answerErr := response.SendWithContext(ctx); answerErr != nil && err == nil {
+					err = fmt.Errorf("cannot answer callback query: %w", answerErr)
+				}
+			}()
+		}
+
+		if field.isBack(f.name, conv.Event) {
+			if index == 0 {
+				return f.ask(ctx, conv.Message(), index, "")
+			}
+			if err := conv.Transition(f.state(index - 1)); err != nil {
+				return err
+			}
+			return f.ask(ctx, conv.Message(), index-1, "")
+		}
+
+		value, problem := field.parse(f.name, conv.Event, f.texts)
+		if problem != "" {
+			return f.ask(ctx, conv.Message(), index, problem)
+		}
+		conv.Set(field.name, value)
+
+		if index == len(f.fields)-1 {
+			conv.Finish()
+			if f.onSubmit == nil {
+				return nil
+			}
+			return f.onSubmit(ctx, &Result{Event: conv.Event, Values: f.values(conv.Session)})
+		}
+
+		if err := conv.Transition(f.state(index + 1)); err != nil {
+			return err
+		}
+		return f.ask(ctx, conv.Message(), index+1, "")
+	}
+}
+
+// ask sends the question of the field to the chat of the message from the event,
+// the problem with the previous answer is sent before the question
+func (f *Form) ask(ctx context.Context, message *botgolang.Message, index int, problem string) error {
+	field := f.fields[index]
+
+	message.ID = ""
+	message.ContentType = botgolang.Text
+	message.Text = field.prompt
+	if problem != "" {
+		message.Text = problem + "\n\n" + field.prompt
+	}
+	if keyboard := field.keyboard(f.name, index > 0, f.texts); keyboard != nil {
+		message.AttachInlineKeyboard(*keyboard)
+	}
+
+	if err := message.SendWithContext(ctx); err != nil {
+		return fmt.Errorf("cannot ask %q: %w", field.name, err)
+	}
+	return nil
+}
+
+// values returns the answers to the fields of the form
+func (f *Form) values(session *fsm.Session) map[string]string {
+	values := make(map[string]string, len(f.fields))
+	for _, field := range f.fields {
+		values[field.name] = session.Data[field.name]
+	}
+	return values
+}
+
+func eventMessage(event botgolang.Event) *botgolang.Message {
+	if event.Type == botgolang.CALLBACK_QUERY {
+		return event.Payload.CallbackMessage()
+	}
+	return event.Payload.Message()
+}
@@ -0,0 +1,199 @@
+package form
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mail-ru-im/bot-golang/fsm"
+	"github.com/mail-ru-im/bot-golang/internal/bottest"
+)
+
+const (
+	textEvent     = `{"eventId":%d,"type":"newMessage","payload":{"msgId":"1","chat":{"chatId":"chat"},"from":{"userId":"user"},"text":%q}}`
+	fileEvent     = `{"eventId":%d,"type":"newMessage","payload":{"msgId":"1","chat":{"chatId":"chat"},"from":{"userId":"user"},"parts":[{"type":"file","payload":{"fileId":"file_id"}}]}}`
+	callbackEvent = `{"eventId":%d,"type":"callbackQuery","payload":{"queryId":"query","callbackData":%q,"from":{"userId":"user"},"message":{"msgId":"2","chat":{"chatId":"chat"}}}}`
+)
+
+type order struct {
+	Name     string  `form:"name"`
+	Size     string  `form:"size"`
+	Count    int     `form:"count"`
+	Weight   float64 `form:"weight"`
+	Gift     bool    `form:"gift"`
+	Picture  string  `form:"picture"`
+	Internal string  `form:"-"`
+}
+
+func newTestForm(submitted *[]*Result) (*fsm.Machine, *Form) {
+	machine := fsm.New(fsm.Config{})
+	form := New(machine, "order",
+		Text("name", "Your name?"),
+		Choice("size", "Size?", "S", "M", "L"),
+		Number("count", "How many?").Range(1, 10),
+		Number("weight", "Weight?"),
+		YesNo("gift", "Is it a gift?"),
+		File("picture", "Send a picture"),
+	).OnSubmit(func(ctx context.Context, result *Result) error {
+		*submitted = append(*submitted, result)
+		return nil
+	})
+	return machine, form
+}
+
+func TestForm_Fill(t *testing.T) {
+	api := bottest.NewAPI(t)
+	var submitted []*Result
+	machine, form := newTestForm(&submitted)
+	ctx := context.Background()
+
+	require.NoError(t, form.Start(ctx, api.Receive(fmt.Sprintf(textEvent, 1, "/order"))))
+
+	answers := []string{
+		fmt.Sprintf(textEvent, 2, "Alice"),
+		fmt.Sprintf(callbackEvent, 3, "form/order/size/1"),
+		fmt.Sprintf(textEvent, 4, "3"),
+		fmt.Sprintf(textEvent, 5, "1,5"),
+		fmt.Sprintf(callbackEvent, 6, "form/order/gift/yes"),
+		fmt.Sprintf(fileEvent, 7),
+	}
+	for _, answer := range answers {
+		require.NoError(t, machine.Handle(ctx, api.Receive(answer)))
+	}
+
+	require.Len(t, submitted, 1)
+	var result order
+	require.NoError(t, submitted[0].Decode(&result))
+	assert.Equal(t, order{Name: "Alice", Size: "M", Count: 3, Weight: 1.5, Gift: true, Picture: "file_id"}, result)
+
+	session, err := machine.Session(fsm.Key{ChatID: "chat", UserID: "user"})
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	var prompts []string
+	var answered int
+	for _, request := range api.Sent() {
+		switch request.Path {
+		case "/messages/sendText":
+			assert.Equal(t, "chat", request.Params.Get("chatId"))
+			prompts = append(prompts, request.Params.Get("text"))
+		case "/messages/answerCallbackQuery":
+			answered++
+		}
+	}
+	assert.Equal(t, []string{"Your name?", "Size?", "How many?", "Weight?", "Is it a gift?", "Send a picture"}, prompts)
+	assert.Equal(t, 2, answered)
+}
+
+func TestForm_InvalidAnswer(t *testing.T) {
+	api := bottest.NewAPI(t)
+	var submitted []*Result
+	machine, form := newTestForm(&submitted)
+	ctx := context.Background()
+
+	require.NoError(t, form.Start(ctx, api.Receive(fmt.Sprintf(textEvent, 1, "/order"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 2, "Alice"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 3, "XL"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(callbackEvent, 4, "form/order/name/0"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 5, "l"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 6, "11"))))
+
+	sent := api.Sent()
+	last := sent[len(sent)-1]
+	assert.Equal(t, "Please send a number from 1 to 10\n\nHow many?", last.Params.Get("text"))
+
+	session, err := machine.Session(fsm.Key{ChatID: "chat", UserID: "user"})
+	require.NoError(t, err)
+	assert.Equal(t, fsm.State("order/count"), session.State)
+	assert.Equal(t, "L", session.Data["size"])
+	assert.Empty(t, submitted)
+}
+
+func TestForm_Back(t *testing.T) {
+	api := bottest.NewAPI(t)
+	var submitted []*Result
+	machine, form := newTestForm(&submitted)
+	ctx := context.Background()
+
+	require.NoError(t, form.Start(ctx, api.Receive(fmt.Sprintf(textEvent, 1, "/order"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 2, "Alice"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(callbackEvent, 3, "form/order/size/back"))))
+
+	session, err := machine.Session(fsm.Key{ChatID: "chat", UserID: "user"})
+	require.NoError(t, err)
+	assert.Equal(t, fsm.State("order/name"), session.State)
+
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 4, "/back"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 5, "Bob"))))
+
+	session, err = machine.Session(fsm.Key{ChatID: "chat", UserID: "user"})
+	require.NoError(t, err)
+	assert.Equal(t, fsm.State("order/size"), session.State)
+	assert.Equal(t, "Bob", session.Data["name"])
+
+	sent := api.Sent()
+	last := sent[len(sent)-1]
+	assert.Equal(t, "Size?", last.Params.Get("text"))
+	assert.Contains(t, last.Params.Get("inlineKeyboardMarkup"), "form/order/size/back")
+}
+
+func TestForm_WithTexts(t *testing.T) {
+	api := bottest.NewAPI(t)
+	var submitted []*Result
+	machine, form := newTestForm(&submitted)
+	form.WithTexts(Texts{
+		Back:         "« Назад",
+		ChooseOption: "Выберите вариант",
+		NumberRange:  "Введите число от %v до %v",
+	})
+	ctx := context.Background()
+
+	require.NoError(t, form.Start(ctx, api.Receive(fmt.Sprintf(textEvent, 1, "/order"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 2, "Alice"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 3, "XL"))))
+
+	sent := api.Sent()
+	last := sent[len(sent)-1]
+	assert.Equal(t, "Выберите вариант\n\nSize?", last.Params.Get("text"))
+	assert.Contains(t, last.Params.Get("inlineKeyboardMarkup"), "« Назад")
+
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 4, "L"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 5, "11"))))
+	require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, 6, "five"))))
+
+	sent = api.Sent()
+	assert.Equal(t, "Введите число от 1 до 10\n\nHow many?", sent[len(sent)-2].Params.Get("text"))
+	assert.Equal(t, "Please send a number\n\nHow many?", sent[len(sent)-1].Params.Get("text"))
+}
+
+func TestForm_WithTexts_YesNo(t *testing.T) {
+	api := bottest.NewAPI(t)
+	var submitted []*Result
+	machine, form := newTestForm(&submitted)
+	form.WithTexts(Texts{Yes: "Да", No: "Нет", AnswerYesNo: "Ответьте да или нет"})
+	ctx := context.Background()
+
+	require.NoError(t, form.Start(ctx, api.Receive(fmt.Sprintf(textEvent, 1, "/order"))))
+	for i, answer := range []string{"Alice", "L", "2", "1.5", "maybe", "да"} {
+		require.NoError(t, machine.Handle(ctx, api.Receive(fmt.Sprintf(textEvent, i+2, answer))))
+	}
+
+	sent := api.Sent()
+	assert.Equal(t, "Ответьте да или нет\n\nIs it a gift?", sent[len(sent)-2].Params.Get("text"))
+	assert.Equal(t, "Send a picture", sent[len(sent)-1].Params.Get("text"))
+}
+
+func TestResult_Decode(t *testing.T) {
+	result := &Result{Values: map[string]string{"Name": "Alice", "count": "x"}}
+
+	var dst struct {
+		Name  string
+		Count int `form:"count"`
+	}
+	assert.Error(t, result.Decode(&dst))
+	assert.Equal(t, "Alice", dst.Name)
+	assert.Error(t, result.Decode(dst))
+}
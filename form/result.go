@@ -0,0 +1,89 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+// Result is the filled form
+type Result struct {
+	// Event with the last answer
+	Event botgolang.Event
+
+	// Answers by names of fields: texts, options, ids of files, numbers and true/false for yes/no questions
+	Values map[string]string
+}
+
+// Decode stores the answers in the struct pointed to by dst.
+// Fields of the struct are matched by the form tag or by their names,
+// string, bool, integer and float fields are supported.
+func (r *Result) Decode(dst interface{}) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return errors.New("destination must be a pointer to a struct")
+	}
+	value = value.Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Tag.Get("form")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		answer, ok := r.Values[name]
+		if !ok {
+			continue
+		}
+		if err := setValue(value.Field(i), answer); err != nil {
+			return fmt.Errorf("cannot decode field %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func setValue(field reflect.Value, answer string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(answer)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(answer)
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(answer, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(answer, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(answer, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(v)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package form
+
+import "fmt"
+
+// Texts are the labels of buttons and the problems sent to the user when an answer is not accepted.
+// Set them with Form.WithTexts to translate the form, empty texts are taken from DefaultTexts.
+type Texts struct {
+	// Labels of the buttons of yes/no questions
+	Yes string
+	No  string
+
+	// Label of the button returning to the previous question
+	Back string
+
+	// Problems with answers to the fields of each kind
+	SendText     string
+	ChooseOption string
+	AnswerYesNo  string
+	SendNumber   string
+	SendFile     string
+
+	// NumberRange is a format with the min and max values of the number, e.g. "from %[1]v to %[2]v"
+	NumberRange string
+
+	// WrongButton is sent when a button of another question is pressed
+	WrongButton string
+}
+
+// DefaultTexts returns English texts
+func DefaultTexts() Texts {
+	return Texts{
+		Yes:          "Yes",
+		No:           "No",
+		Back:         "« Back",
+		SendText:     "Please send a text",
+		ChooseOption: "Please choose one of the options",
+		AnswerYesNo:  "Please answer yes or no",
+		SendNumber:   "Please send a number",
+		SendFile:     "Please send a file",
+		NumberRange:  "Please send a number from %v to %v",
+		WrongButton:  "This button is not for this question",
+	}
+}
+
+// withDefaults returns the texts with empty ones replaced by defaults
+func (t Texts) withDefaults() Texts {
+	defaults := DefaultTexts()
+	for _, text := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&t.Yes, defaults.Yes},
+		{&t.No, defaults.No},
+		{&t.Back, defaults.Back},
+		{&t.SendText, defaults.SendText},
+		{&t.ChooseOption, defaults.ChooseOption},
+		{&t.AnswerYesNo, defaults.AnswerYesNo},
+		{&t.SendNumber, defaults.SendNumber},
+		{&t.SendFile, defaults.SendFile},
+		{&t.NumberRange, defaults.NumberRange},
+		{&t.WrongButton, defaults.WrongButton},
+	} {
+		if *text.value == "" {
+			*text.value = text.fallback
+		}
+	}
+	return t
+}
+
+func (t Texts) numberRange(min, max float64) string {
+	return fmt.Sprintf(t.NumberRange, min, max)
+}
@@ -0,0 +1,272 @@
+package botgolang
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// FormatRange is a formatted part of the text.
+// Offset and Length are counted in UTF-16 code units, use UTF16Len to convert Go strings.
+type FormatRange struct {
+	Offset int `json:"offset"`
+	Length int `json:"length"`
+}
+
+// LinkRange is a part of the text linked to the URL
+type LinkRange struct {
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+	URL    string `json:"url"`
+}
+
+// PreRange is a block of code
+type PreRange struct {
+	Offset       int    `json:"offset"`
+	Length       int    `json:"length"`
+	CodeLanguage string `json:"code_language,omitempty"`
+}
+
+// Format describes formatting of the message text with ranges instead of markup,
+// the text doesn't need to be escaped. It is sent as the format parameter.
+type Format struct {
+	Bold          []FormatRange `json:"bold,omitempty"`
+	Italic        []FormatRange `json:"italic,omitempty"`
+	Underline     []FormatRange `json:"underline,omitempty"`
+	Strikethrough []FormatRange `json:"strikethrough,omitempty"`
+	Link          []LinkRange   `json:"link,omitempty"`
+	Mention       []FormatRange `json:"mention,omitempty"`
+	InlineCode    []FormatRange `json:"inline_code,omitempty"`
+	Pre           []PreRange    `json:"pre,omitempty"`
+	OrderedList   []FormatRange `json:"ordered_list,omitempty"`
+	UnorderedList []FormatRange `json:"unordered_list,omitempty"`
+	Quote         []FormatRange `json:"quote,omitempty"`
+}
+
+// UTF16Len returns the length of the string in UTF-16 code units
+func UTF16Len(s string) int {
+	length := 0
+	for _, r := range s {
+		length += utf16.RuneLen(r)
+	}
+	return length
+}
+
+// FormatBuilder builds the text and its format together, so offsets are always correct
+type FormatBuilder struct {
+	text   []byte
+	length int
+	format Format
+}
+
+// NewFormatBuilder returns an empty builder
+func NewFormatBuilder() *FormatBuilder {
+	return &FormatBuilder{}
+}
+
+// write appends the text and returns its range
+func (b *FormatBuilder) write(text string) FormatRange {
+	r := FormatRange{Offset: b.length, Length: UTF16Len(text)}
+	b.text = append(b.text, text...)
+	b.length += r.Length
+	return r
+}
+
+// Text appends the text without formatting
+func (b *FormatBuilder) Text(text string) *FormatBuilder {
+	b.write(text)
+	return b
+}
+
+// Bold appends bold text
+func (b *FormatBuilder) Bold(text string) *FormatBuilder {
+	b.format.Bold = append(b.format.Bold, b.write(text))
+	return b
+}
+
+// Italic appends italic text
+func (b *FormatBuilder) Italic(text string) *FormatBuilder {
+	b.format.Italic = append(b.format.Italic, b.write(text))
+	return b
+}
+
+// Underline appends underlined text
+func (b *FormatBuilder) Underline(text string) *FormatBuilder {
+	b.format.Underline = append(b.format.Underline, b.write(text))
+	return b
+}
+
+// Strikethrough appends strikethrough text
+func (b *FormatBuilder) Strikethrough(text string) *FormatBuilder {
+	b.format.Strikethrough = append(b.format.Strikethrough, b.write(text))
+	return b
+}
+
+// Link appends the text linked to the url
+func (b *FormatBuilder) Link(text, url string) *FormatBuilder {
+	r := b.write(text)
+	b.format.Link = append(b.format.Link, LinkRange{Offset: r.Offset, Length: r.Length, URL: url})
+	return b
+}
+
+// Mention appends the mention of the user, the text is @[userID]
+func (b *FormatBuilder) Mention(userID string) *FormatBuilder {
+	b.format.Mention = append(b.format.Mention, b.write("@["+userID+"]"))
+	return b
+}
+
+// InlineCode appends inline code
+func (b *FormatBuilder) InlineCode(text string) *FormatBuilder {
+	b.format.InlineCode = append(b.format.InlineCode, b.write(text))
+	return b
+}
+
+// Pre appends a block of code, the language may be empty
+func (b *FormatBuilder) Pre(text, language string) *FormatBuilder {
+	r := b.write(text)
+	b.format.Pre = append(b.format.Pre, PreRange{Offset: r.Offset, Length: r.Length, CodeLanguage: language})
+	return b
+}
+
+// Quote appends a quote
+func (b *FormatBuilder) Quote(text string) *FormatBuilder {
+	b.format.Quote = append(b.format.Quote, b.write(text))
+	return b
+}
+
+// OrderedList appends a numbered list, items are separated by line breaks
+func (b *FormatBuilder) OrderedList(items ...string) *FormatBuilder {
+	b.format.OrderedList = append(b.format.OrderedList, b.write(strings.Join(items, "\n")))
+	return b
+}
+
+// UnorderedList appends a bulleted list, items are separated by line breaks
+func (b *FormatBuilder) UnorderedList(items ...string) *FormatBuilder {
+	b.format.UnorderedList = append(b.format.UnorderedList, b.write(strings.Join(items, "\n")))
+	return b
+}
+
+// Build returns the text and its format
+func (b *FormatBuilder) Build() (string, *Format) {
+	format := b.format
+	return string(b.text), &format
+}
+
+// formatSpan is a range of the format with its type, used for validation
+type formatSpan struct {
+	kind   string
+	offset int
+	end    int
+}
+
+// spans returns all ranges of the format
+func (f *Format) spans() []formatSpan {
+	var spans []formatSpan
+	add := func(kind string, ranges []FormatRange) {
+		for _, r := range ranges {
+			spans = append(spans, formatSpan{kind: kind, offset: r.Offset, end: r.Offset + r.Length})
+		}
+	}
+
+	add("bold", f.Bold)
+	add("italic", f.Italic)
+	add("underline", f.Underline)
+	add("strikethrough", f.Strikethrough)
+	add("mention", f.Mention)
+	add("inline_code", f.InlineCode)
+	add("ordered_list", f.OrderedList)
+	add("unordered_list", f.UnorderedList)
+	add("quote", f.Quote)
+	for _, r := range f.Link {
+		spans = append(spans, formatSpan{kind: "link", offset: r.Offset, end: r.Offset + r.Length})
+	}
+	for _, r := range f.Pre {
+		spans = append(spans, formatSpan{kind: "pre", offset: r.Offset, end: r.Offset + r.Length})
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].offset < spans[j].offset
+	})
+	return spans
+}
+
+// atomicFormats cannot overlap each other, formatting inside code is not supported
+var atomicFormats = map[string]bool{
+	"link":        true,
+	"mention":     true,
+	"inline_code": true,
+	"pre":         true,
+}
+
+// codeFormats cannot contain other formatting except blocks
+var codeFormats = map[string]bool{
+	"inline_code": true,
+	"pre":         true,
+}
+
+var blockFormats = map[string]bool{
+	"ordered_list":   true,
+	"unordered_list": true,
+	"quote":          true,
+	"pre":            true,
+}
+
+// Validate checks that the ranges are inside the text and do not overlap illegally:
+// ranges of the same type, links, mentions and code cannot overlap each other,
+// and code cannot overlap other formatting except lists and quotes.
+func (f *Format) Validate(text string) error {
+	length := UTF16Len(text)
+	spans := f.spans()
+
+	for i, span := range spans {
+		if span.offset < 0 || span.end <= span.offset {
+			return fmt.Errorf("%w: %s range at %d has invalid length", ErrInvalidFormat, span.kind, span.offset)
+		}
+		if span.end > length {
+			return fmt.Errorf("%w: %s range at %d is outside of the text of length %d", ErrInvalidFormat, span.kind, span.offset, length)
+		}
+
+		for _, next := range spans[i+1:] {
+			if next.offset >= span.end {
+				break
+			}
+			if !overlapAllowed(span.kind, next.kind) {
+				return fmt.Errorf("%w: %s range at %d overlaps %s range at %d", ErrInvalidFormat, span.kind, span.offset, next.kind, next.offset)
+			}
+		}
+	}
+
+	return nil
+}
+
+func overlapAllowed(a, b string) bool {
+	switch {
+	case a == b:
+		return false
+	case atomicFormats[a] && atomicFormats[b]:
+		return false
+	case codeFormats[a] && !blockFormats[b], codeFormats[b] && !blockFormats[a]:
+		return false
+	}
+	return true
+}
+
+// setFormat validates the format of the message and adds it to the request parameters
+func setFormat(params url.Values, message *Message) error {
+	if message.Format == nil {
+		return nil
+	}
+	if err := message.Format.Validate(message.Text); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(message.Format)
+	if err != nil {
+		return fmt.Errorf("cannot marshal format: %w", err)
+	}
+	params.Set("format", string(data))
+	return nil
+}
@@ -0,0 +1,887 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package botgolang
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson72863a49DecodeGithubComMailRuImBotGolang(in *jlexer.Lexer, out *formatSpan) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson72863a49EncodeGithubComMailRuImBotGolang(out *jwriter.Writer, in formatSpan) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v formatSpan) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson72863a49EncodeGithubComMailRuImBotGolang(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v formatSpan) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson72863a49EncodeGithubComMailRuImBotGolang(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *formatSpan) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson72863a49DecodeGithubComMailRuImBotGolang(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *formatSpan) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson72863a49DecodeGithubComMailRuImBotGolang(l, v)
+}
+func easyjson72863a49DecodeGithubComMailRuImBotGolang1(in *jlexer.Lexer, out *PreRange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "offset":
+			out.Offset = int(in.Int())
+		case "length":
+			out.Length = int(in.Int())
+		case "code_language":
+			out.CodeLanguage = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson72863a49EncodeGithubComMailRuImBotGolang1(out *jwriter.Writer, in PreRange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Offset))
+	}
+	{
+		const prefix string = ",\"length\":"
+		out.RawString(prefix)
+		out.Int(int(in.Length))
+	}
+	if in.CodeLanguage != "" {
+		const prefix string = ",\"code_language\":"
+		out.RawString(prefix)
+		out.String(string(in.CodeLanguage))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PreRange) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson72863a49EncodeGithubComMailRuImBotGolang1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PreRange) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson72863a49EncodeGithubComMailRuImBotGolang1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PreRange) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson72863a49DecodeGithubComMailRuImBotGolang1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PreRange) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson72863a49DecodeGithubComMailRuImBotGolang1(l, v)
+}
+func easyjson72863a49DecodeGithubComMailRuImBotGolang2(in *jlexer.Lexer, out *LinkRange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "offset":
+			out.Offset = int(in.Int())
+		case "length":
+			out.Length = int(in.Int())
+		case "url":
+			out.URL = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson72863a49EncodeGithubComMailRuImBotGolang2(out *jwriter.Writer, in LinkRange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Offset))
+	}
+	{
+		const prefix string = ",\"length\":"
+		out.RawString(prefix)
+		out.Int(int(in.Length))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LinkRange) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson72863a49EncodeGithubComMailRuImBotGolang2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LinkRange) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson72863a49EncodeGithubComMailRuImBotGolang2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LinkRange) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson72863a49DecodeGithubComMailRuImBotGolang2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LinkRange) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson72863a49DecodeGithubComMailRuImBotGolang2(l, v)
+}
+func easyjson72863a49DecodeGithubComMailRuImBotGolang3(in *jlexer.Lexer, out *FormatRange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "offset":
+			out.Offset = int(in.Int())
+		case "length":
+			out.Length = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson72863a49EncodeGithubComMailRuImBotGolang3(out *jwriter.Writer, in FormatRange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Offset))
+	}
+	{
+		const prefix string = ",\"length\":"
+		out.RawString(prefix)
+		out.Int(int(in.Length))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FormatRange) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson72863a49EncodeGithubComMailRuImBotGolang3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FormatRange) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson72863a49EncodeGithubComMailRuImBotGolang3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FormatRange) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson72863a49DecodeGithubComMailRuImBotGolang3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FormatRange) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson72863a49DecodeGithubComMailRuImBotGolang3(l, v)
+}
+func easyjson72863a49DecodeGithubComMailRuImBotGolang4(in *jlexer.Lexer, out *FormatBuilder) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson72863a49EncodeGithubComMailRuImBotGolang4(out *jwriter.Writer, in FormatBuilder) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FormatBuilder) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson72863a49EncodeGithubComMailRuImBotGolang4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FormatBuilder) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson72863a49EncodeGithubComMailRuImBotGolang4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FormatBuilder) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson72863a49DecodeGithubComMailRuImBotGolang4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FormatBuilder) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson72863a49DecodeGithubComMailRuImBotGolang4(l, v)
+}
+func easyjson72863a49DecodeGithubComMailRuImBotGolang5(in *jlexer.Lexer, out *Format) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "bold":
+			if in.IsNull() {
+				in.Skip()
+				out.Bold = nil
+			} else {
+				in.Delim('[')
+				if out.Bold == nil {
+					if !in.IsDelim(']') {
+						out.Bold = make([]FormatRange, 0, 4)
+					} else {
+						out.Bold = []FormatRange{}
+					}
+				} else {
+					out.Bold = (out.Bold)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 FormatRange
+					(v1).UnmarshalEasyJSON(in)
+					out.Bold = append(out.Bold, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "italic":
+			if in.IsNull() {
+				in.Skip()
+				out.Italic = nil
+			} else {
+				in.Delim('[')
+				if out.Italic == nil {
+					if !in.IsDelim(']') {
+						out.Italic = make([]FormatRange, 0, 4)
+					} else {
+						out.Italic = []FormatRange{}
+					}
+				} else {
+					out.Italic = (out.Italic)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 FormatRange
+					(v2).UnmarshalEasyJSON(in)
+					out.Italic = append(out.Italic, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "underline":
+			if in.IsNull() {
+				in.Skip()
+				out.Underline = nil
+			} else {
+				in.Delim('[')
+				if out.Underline == nil {
+					if !in.IsDelim(']') {
+						out.Underline = make([]FormatRange, 0, 4)
+					} else {
+						out.Underline = []FormatRange{}
+					}
+				} else {
+					out.Underline = (out.Underline)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 FormatRange
+					(v3).UnmarshalEasyJSON(in)
+					out.Underline = append(out.Underline, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "strikethrough":
+			if in.IsNull() {
+				in.Skip()
+				out.Strikethrough = nil
+			} else {
+				in.Delim('[')
+				if out.Strikethrough == nil {
+					if !in.IsDelim(']') {
+						out.Strikethrough = make([]FormatRange, 0, 4)
+					} else {
+						out.Strikethrough = []FormatRange{}
+					}
+				} else {
+					out.Strikethrough = (out.Strikethrough)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 FormatRange
+					(v4).UnmarshalEasyJSON(in)
+					out.Strikethrough = append(out.Strikethrough, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "link":
+			if in.IsNull() {
+				in.Skip()
+				out.Link = nil
+			} else {
+				in.Delim('[')
+				if out.Link == nil {
+					if !in.IsDelim(']') {
+						out.Link = make([]LinkRange, 0, 2)
+					} else {
+						out.Link = []LinkRange{}
+					}
+				} else {
+					out.Link = (out.Link)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 LinkRange
+					(v5).UnmarshalEasyJSON(in)
+					out.Link = append(out.Link, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "mention":
+			if in.IsNull() {
+				in.Skip()
+				out.Mention = nil
+			} else {
+				in.Delim('[')
+				if out.Mention == nil {
+					if !in.IsDelim(']') {
+						out.Mention = make([]FormatRange, 0, 4)
+					} else {
+						out.Mention = []FormatRange{}
+					}
+				} else {
+					out.Mention = (out.Mention)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 FormatRange
+					(v6).UnmarshalEasyJSON(in)
+					out.Mention = append(out.Mention, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "inline_code":
+			if in.IsNull() {
+				in.Skip()
+				out.InlineCode = nil
+			} else {
+				in.Delim('[')
+				if out.InlineCode == nil {
+					if !in.IsDelim(']') {
+						out.InlineCode = make([]FormatRange, 0, 4)
+					} else {
+						out.InlineCode = []FormatRange{}
+					}
+				} else {
+					out.InlineCode = (out.InlineCode)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 FormatRange
+					(v7).UnmarshalEasyJSON(in)
+					out.InlineCode = append(out.InlineCode, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "pre":
+			if in.IsNull() {
+				in.Skip()
+				out.Pre = nil
+			} else {
+				in.Delim('[')
+				if out.Pre == nil {
+					if !in.IsDelim(']') {
+						out.Pre = make([]PreRange, 0, 2)
+					} else {
+						out.Pre = []PreRange{}
+					}
+				} else {
+					out.Pre = (out.Pre)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 PreRange
+					(v8).UnmarshalEasyJSON(in)
+					out.Pre = append(out.Pre, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "ordered_list":
+			if in.IsNull() {
+				in.Skip()
+				out.OrderedList = nil
+			} else {
+				in.Delim('[')
+				if out.OrderedList == nil {
+					if !in.IsDelim(']') {
+						out.OrderedList = make([]FormatRange, 0, 4)
+					} else {
+						out.OrderedList = []FormatRange{}
+					}
+				} else {
+					out.OrderedList = (out.OrderedList)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v9 FormatRange
+					(v9).UnmarshalEasyJSON(in)
+					out.OrderedList = append(out.OrderedList, v9)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "unordered_list":
+			if in.IsNull() {
+				in.Skip()
+				out.UnorderedList = nil
+			} else {
+				in.Delim('[')
+				if out.UnorderedList == nil {
+					if !in.IsDelim(']') {
+						out.UnorderedList = make([]FormatRange, 0, 4)
+					} else {
+						out.UnorderedList = []FormatRange{}
+					}
+				} else {
+					out.UnorderedList = (out.UnorderedList)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 FormatRange
+					(v10).UnmarshalEasyJSON(in)
+					out.UnorderedList = append(out.UnorderedList, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "quote":
+			if in.IsNull() {
+				in.Skip()
+				out.Quote = nil
+			} else {
+				in.Delim('[')
+				if out.Quote == nil {
+					if !in.IsDelim(']') {
+						out.Quote = make([]FormatRange, 0, 4)
+					} else {
+						out.Quote = []FormatRange{}
+					}
+				} else {
+					out.Quote = (out.Quote)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 FormatRange
+					(v11).UnmarshalEasyJSON(in)
+					out.Quote = append(out.Quote, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson72863a49EncodeGithubComMailRuImBotGolang5(out *jwriter.Writer, in Format) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.Bold) != 0 {
+		const prefix string = ",\"bold\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v12, v13 := range in.Bold {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				(v13).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Italic) != 0 {
+		const prefix string = ",\"italic\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.Italic {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				(v15).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Underline) != 0 {
+		const prefix string = ",\"underline\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v16, v17 := range in.Underline {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				(v17).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Strikethrough) != 0 {
+		const prefix string = ",\"strikethrough\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v18, v19 := range in.Strikethrough {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				(v19).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Link) != 0 {
+		const prefix string = ",\"link\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.Link {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				(v21).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Mention) != 0 {
+		const prefix string = ",\"mention\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v22, v23 := range in.Mention {
+				if v22 > 0 {
+					out.RawByte(',')
+				}
+				(v23).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.InlineCode) != 0 {
+		const prefix string = ",\"inline_code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v24, v25 := range in.InlineCode {
+				if v24 > 0 {
+					out.RawByte(',')
+				}
+				(v25).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Pre) != 0 {
+		const prefix string = ",\"pre\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v26, v27 := range in.Pre {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				(v27).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.OrderedList) != 0 {
+		const prefix string = ",\"ordered_list\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v28, v29 := range in.OrderedList {
+				if v28 > 0 {
+					out.RawByte(',')
+				}
+				(v29).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.UnorderedList) != 0 {
+		const prefix string = ",\"unordered_list\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v30, v31 := range in.UnorderedList {
+				if v30 > 0 {
+					out.RawByte(',')
+				}
+				(v31).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Quote) != 0 {
+		const prefix string = ",\"quote\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v32, v33 := range in.Quote {
+				if v32 > 0 {
+					out.RawByte(',')
+				}
+				(v33).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Format) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson72863a49EncodeGithubComMailRuImBotGolang5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Format) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson72863a49EncodeGithubComMailRuImBotGolang5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Format) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson72863a49DecodeGithubComMailRuImBotGolang5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Format) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson72863a49DecodeGithubComMailRuImBotGolang5(l, v)
+}
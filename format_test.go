@@ -0,0 +1,76 @@
+package botgolang
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatBuilder_UTF16(t *testing.T) {
+	text, format := NewFormatBuilder().
+		Text("Привет 👋 ").
+		Bold("мир").
+		Text(" ").
+		Link("docs", "https://example.com").
+		Build()
+
+	assert.Equal(t, "Привет 👋 мир docs", text)
+	assert.Equal(t, []FormatRange{{Offset: 10, Length: 3}}, format.Bold)
+	assert.Equal(t, []LinkRange{{Offset: 14, Length: 4, URL: "https://example.com"}}, format.Link)
+	assert.NoError(t, format.Validate(text))
+}
+
+func TestFormat_Validate(t *testing.T) {
+	text := "0123456789"
+
+	tests := []struct {
+		name   string
+		format Format
+		valid  bool
+	}{
+		{name: "nested styles", format: Format{Bold: []FormatRange{{0, 10}}, Italic: []FormatRange{{2, 3}}}, valid: true},
+		{name: "code in quote", format: Format{Quote: []FormatRange{{0, 10}}, InlineCode: []FormatRange{{2, 3}}}, valid: true},
+		{name: "outside of text", format: Format{Bold: []FormatRange{{5, 6}}}},
+		{name: "negative offset", format: Format{Bold: []FormatRange{{-1, 2}}}},
+		{name: "empty range", format: Format{Italic: []FormatRange{{1, 0}}}},
+		{name: "same type", format: Format{Bold: []FormatRange{{0, 5}, {4, 2}}}},
+		{name: "link and mention", format: Format{Link: []LinkRange{{0, 5, "u"}}, Mention: []FormatRange{{3, 4}}}},
+		{name: "style in code", format: Format{Pre: []PreRange{{0, 5, "go"}}, Bold: []FormatRange{{1, 2}}}},
+		{name: "adjacent", format: Format{Bold: []FormatRange{{0, 5}, {5, 5}}}, valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.format.Validate(text)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidFormat)
+			}
+		})
+	}
+}
+
+func TestClient_SendTextMessage_Format(t *testing.T) {
+	client := NewApiMockClient(t)
+
+	var params url.Values
+	client.interceptors = []Interceptor{
+		func(ctx context.Context, call *APICall, next Invoker) (*APIResult, error) {
+			params = call.Params
+			return next(ctx, call)
+		},
+	}
+
+	text, format := NewFormatBuilder().Bold("hi").Text(" ").Pre("x := 1", "go").Build()
+	require.NoError(t, client.SendTextMessage(&Message{Chat: Chat{ID: "chat"}, Text: text, Format: format}))
+	assert.JSONEq(t, `{"bold":[{"offset":0,"length":2}],"pre":[{"offset":3,"length":6,"code_language":"go"}]}`, params.Get("format"))
+
+	params = nil
+	err := client.SendFileMessage(&Message{Chat: Chat{ID: "chat"}, FileID: "file", Text: "caption", Format: &Format{Bold: []FormatRange{{0, 10}}}})
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	assert.Nil(t, params)
+}
@@ -0,0 +1,375 @@
+// Package fsm tracks multi-step conversations with users.
+// Every conversation is a session keyed by chat and user, it has a state
+// and a handler of the state receives the next events of the user in the chat.
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+const locksCount = 64
+
+var (
+	// ErrUnknownState is returned on transition to a state which is not registered
+	ErrUnknownState = errors.New("unknown state")
+
+	// ErrInvalidTransition is returned on transition which is not allowed from the current state
+	ErrInvalidTransition = errors.New("invalid transition")
+)
+
+// State of a conversation
+type State string
+
+// Key identifies a conversation of the user in the chat
+type Key struct {
+	ChatID string `json:"chatId"`
+	UserID string `json:"userId"`
+}
+
+// KeyOf returns the key of the conversation the event belongs to,
+// the chat of callback queries is taken from the message with the button
+func KeyOf(event botgolang.Event) Key {
+	return Key{ChatID: event.ChatID(), UserID: event.Payload.From.User.ID}
+}
+
+// Session is the stored state of a conversation
+type Session struct {
+	Key     Key               `json:"key"`
+	State   State             `json:"state"`
+	Data    map[string]string `json:"data,omitempty"`
+	Updated time.Time         `json:"updated"`
+}
+
+func (s *Session) clone() *Session {
+	clone := *s
+	if s.Data != nil {
+		clone.Data = make(map[string]string, len(s.Data))
+		for key, value := range s.Data {
+			clone.Data[key] = value
+		}
+	}
+	return &clone
+}
+
+// Handler handles an event of the conversation
+type Handler func(ctx context.Context, conv *Conversation) error
+
+// TimeoutHandler cleans up after the conversation which expired
+type TimeoutHandler func(ctx context.Context, session *Session) error
+
+// Conversation is passed to handlers, changes of the session are saved after the handler returns
+type Conversation struct {
+	// Session of the conversation
+	Session *Session
+
+	// Event received in the conversation
+	Event botgolang.Event
+
+	machine  *Machine
+	finished bool
+}
+
+// Get returns the value stored in the session
+func (c *Conversation) Get(key string) string {
+	return c.Session.Data[key]
+}
+
+// Set stores the value in the session
+func (c *Conversation) Set(key, value string) {
+	if c.Session.Data == nil {
+		c.Session.Data = make(map[string]string)
+	}
+	c.Session.Data[key] = value
+}
+
+// Transition moves the conversation to the state
+func (c *Conversation) Transition(state State) error {
+	if err := c.machine.checkTransition(c.Session.State, state); err != nil {
+		return err
+	}
+	c.Session.State = state
+	c.finished = false
+	return nil
+}
+
+// Finish ends the conversation, its session is deleted
+func (c *Conversation) Finish() {
+	c.finished = true
+}
+
+// Message returns the message of the event, the message with the button for callback queries
+func (c *Conversation) Message() *botgolang.Message {
+	if c.Event.Type == botgolang.CALLBACK_QUERY {
+		return c.Event.Payload.CallbackMessage()
+	}
+	return c.Event.Payload.Message()
+}
+
+// Config configures the state machine
+type Config struct {
+	// Store of sessions, defaults to in-memory store
+	Store SessionStore
+
+	// Conversations without events for this time expire, zero means they never expire
+	Timeout time.Duration
+
+	// OnTimeout is called for expired conversations before their sessions are deleted
+	OnTimeout TimeoutHandler
+
+	// Texts of messages which cancel the conversation, defaults to /cancel
+	CancelCommands []string
+
+	// OnCancel is called when the conversation is cancelled before its session is deleted
+	OnCancel Handler
+
+	// Fallback handles events which do not belong to a conversation
+	Fallback botgolang.Handler
+}
+
+type stateRoute struct {
+	handler     Handler
+	transitions map[State]bool
+}
+
+// Machine routes events of conversations to handlers of their states, it implements botgolang.Handler
+type Machine struct {
+	config Config
+	states map[State]*stateRoute
+	locks  [locksCount]sync.Mutex
+	now    func() time.Time
+}
+
+// New returns new state machine
+func New(config Config) *Machine {
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+	if config.CancelCommands == nil {
+		config.CancelCommands = []string{"/cancel"}
+	}
+
+	return &Machine{
+		config: config,
+		states: make(map[State]*stateRoute),
+		now:    time.Now,
+	}
+}
+
+// State registers the handler of the state.
+// If transitions are given, only these states can follow the state.
+func (m *Machine) State(state State, handler Handler, transitions ...State) {
+	route := &stateRoute{handler: handler}
+	if len(transitions) > 0 {
+		route.transitions = make(map[State]bool, len(transitions))
+		for _, transition := range transitions {
+			route.transitions[transition] = true
+		}
+	}
+	m.states[state] = route
+}
+
+func (m *Machine) checkTransition(from, to State) error {
+	if _, ok := m.states[to]; !ok {
+		return fmt.Errorf("state %q: %w", to, ErrUnknownState)
+	}
+	if route, ok := m.states[from]; ok && route.transitions != nil && !route.transitions[to] {
+		return fmt.Errorf("from %q to %q: %w", from, to, ErrInvalidTransition)
+	}
+	return nil
+}
+
+// Start begins the conversation of the event in the state, the current conversation is replaced.
+// It must not be called from state handlers of the machine, they use Conversation.Transition.
+func (m *Machine) Start(ctx context.Context, event botgolang.Event, state State) error {
+	return m.StartWithData(ctx, event, state, nil)
+}
+
+// StartWithData begins the conversation of the event in the state with initial data
+func (m *Machine) StartWithData(ctx context.Context, event botgolang.Event, state State, data map[string]string) error {
+	if _, ok := m.states[state]; !ok {
+		return fmt.Errorf("state %q: %w", state, ErrUnknownState)
+	}
+
+	key := KeyOf(event)
+	lock := m.lock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	session := &Session{Key: key, State: state, Data: data, Updated: m.now()}
+	if err := m.config.Store.Save(session); err != nil {
+		return fmt.Errorf("cannot save session: %w", err)
+	}
+	return nil
+}
+
+// Session returns the session of the conversation or nil if there is no conversation
+func (m *Machine) Session(key Key) (*Session, error) {
+	return m.config.Store.Load(key)
+}
+
+// Handle passes the event to the handler of the conversation state.
+// Events without a conversation are passed to the fallback handler, it may start a conversation.
+func (m *Machine) Handle(ctx context.Context, event botgolang.Event) error {
+	handled, err := m.handle(ctx, event)
+	if handled || err != nil {
+		return err
+	}
+
+	if m.config.Fallback != nil {
+		return m.config.Fallback.Handle(ctx, event)
+	}
+	return nil
+}
+
+// handle passes the event to the conversation, it returns false if there is no conversation
+func (m *Machine) handle(ctx context.Context, event botgolang.Event) (bool, error) {
+	key := KeyOf(event)
+	lock := m.lock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	session, err := m.config.Store.Load(key)
+	if err != nil {
+		return false, fmt.Errorf("cannot load session: %w", err)
+	}
+	if session == nil {
+		return false, nil
+	}
+
+	if m.expired(session) {
+		return false, m.expire(ctx, session)
+	}
+
+	route, ok := m.states[session.State]
+	if !ok {
+		return false, nil
+	}
+
+	conv := &Conversation{Session: session, Event: event, machine: m}
+
+	if m.isCancel(event) {
+		if m.config.OnCancel != nil {
+			if err := m.config.OnCancel(ctx, conv); err != nil {
+				return true, err
+			}
+		}
+		return true, m.delete(key)
+	}
+
+	handlerErr := route.handler(ctx, conv)
+
+	if conv.finished {
+		if err := m.delete(key); err != nil {
+			return true, err
+		}
+		return true, handlerErr
+	}
+
+	conv.Session.Updated = m.now()
+	if err := m.config.Store.Save(conv.Session); err != nil {
+		return true, fmt.Errorf("cannot save session: %w", err)
+	}
+	return true, handlerErr
+}
+
+// Sweep cleans up expired conversations
+func (m *Machine) Sweep(ctx context.Context) error {
+	if m.config.Timeout <= 0 {
+		return nil
+	}
+
+	sessions, err := m.config.Store.Expired(m.now().Add(-m.config.Timeout))
+	if err != nil {
+		return fmt.Errorf("cannot get expired sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		if err := m.sweep(ctx, session.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sweep expires the session if it is still expired under the lock
+func (m *Machine) sweep(ctx context.Context, key Key) error {
+	lock := m.lock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	session, err := m.config.Store.Load(key)
+	if err != nil {
+		return fmt.Errorf("cannot load session: %w", err)
+	}
+	if session == nil || !m.expired(session) {
+		return nil
+	}
+	return m.expire(ctx, session)
+}
+
+// Run sweeps expired conversations with the interval until the context is done
+func (m *Machine) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := m.Sweep(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (m *Machine) expired(session *Session) bool {
+	return m.config.Timeout > 0 && m.now().Sub(session.Updated) > m.config.Timeout
+}
+
+func (m *Machine) expire(ctx context.Context, session *Session) error {
+	if m.config.OnTimeout != nil {
+		if err := m.config.OnTimeout(ctx, session); err != nil {
+			return err
+		}
+	}
+	return m.delete(session.Key)
+}
+
+func (m *Machine) delete(key Key) error {
+	if err := m.config.Store.Delete(key); err != nil {
+		return fmt.Errorf("cannot delete session: %w", err)
+	}
+	return nil
+}
+
+func (m *Machine) isCancel(event botgolang.Event) bool {
+	if event.Type != botgolang.NEW_MESSAGE {
+		return false
+	}
+
+	text := strings.TrimSpace(event.Payload.Text)
+	for _, command := range m.config.CancelCommands {
+		if strings.EqualFold(text, command) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Machine) lock(key Key) *sync.Mutex {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key.ChatID))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(key.UserID))
+	return &m.locks[hash.Sum32()%locksCount]
+}
@@ -0,0 +1,189 @@
+package fsm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvent(text string) botgolang.Event {
+	return botgolang.Event{
+		Type: botgolang.NEW_MESSAGE,
+		Payload: botgolang.EventPayload{BaseEventPayload: botgolang.BaseEventPayload{
+			Chat: botgolang.Chat{ID: "chat"},
+			From: botgolang.Contact{User: botgolang.User{ID: "user"}},
+			Text: text,
+		}},
+	}
+}
+
+var testKey = Key{ChatID: "chat", UserID: "user"}
+
+func newTestMachine(t *testing.T, config Config) *Machine {
+	t.Helper()
+
+	var machine *Machine
+	config.Fallback = botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+		if event.Payload.Text == "/report" {
+			return machine.Start(ctx, event, "date")
+		}
+		return nil
+	})
+	machine = New(config)
+
+	machine.State("date", func(ctx context.Context, conv *Conversation) error {
+		conv.Set("date", conv.Event.Payload.Text)
+		return conv.Transition("reason")
+	}, "reason")
+	machine.State("reason", func(ctx context.Context, conv *Conversation) error {
+		if conv.Event.Payload.Text == "skip" {
+			return conv.Transition("date")
+		}
+		conv.Set("reason", conv.Event.Payload.Text)
+		return conv.Transition("confirm")
+	}, "confirm")
+	machine.State("confirm", func(ctx context.Context, conv *Conversation) error {
+		conv.Finish()
+		return nil
+	})
+
+	return machine
+}
+
+func TestMachine_Handle(t *testing.T) {
+	machine := newTestMachine(t, Config{})
+	ctx := context.Background()
+
+	require.NoError(t, machine.Handle(ctx, newTestEvent("hello")))
+	session, err := machine.Session(testKey)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	require.NoError(t, machine.Handle(ctx, newTestEvent("/report")))
+	require.NoError(t, machine.Handle(ctx, newTestEvent("2024-05-01")))
+
+	// the transition is not allowed, the state is not changed
+	err = machine.Handle(ctx, newTestEvent("skip"))
+	require.ErrorIs(t, err, ErrInvalidTransition)
+
+	require.NoError(t, machine.Handle(ctx, newTestEvent("vacation")))
+
+	session, err = machine.Session(testKey)
+	require.NoError(t, err)
+	assert.Equal(t, State("confirm"), session.State)
+	assert.Equal(t, map[string]string{"date": "2024-05-01", "reason": "vacation"}, session.Data)
+
+	require.NoError(t, machine.Handle(ctx, newTestEvent("yes")))
+	session, err = machine.Session(testKey)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	require.ErrorIs(t, machine.Start(ctx, newTestEvent(""), "unknown"), ErrUnknownState)
+}
+
+func TestMachine_Cancel(t *testing.T) {
+	var cancelled *Session
+	machine := newTestMachine(t, Config{
+		OnCancel: func(ctx context.Context, conv *Conversation) error {
+			cancelled = conv.Session
+			return nil
+		},
+	})
+	ctx := context.Background()
+
+	require.NoError(t, machine.Handle(ctx, newTestEvent("/report")))
+	require.NoError(t, machine.Handle(ctx, newTestEvent(" /CANCEL ")))
+
+	require.NotNil(t, cancelled)
+	assert.Equal(t, State("date"), cancelled.State)
+	session, err := machine.Session(testKey)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestMachine_Timeout(t *testing.T) {
+	var expired []Key
+	machine := newTestMachine(t, Config{
+		Timeout: time.Minute,
+		OnTimeout: func(ctx context.Context, session *Session) error {
+			expired = append(expired, session.Key)
+			return nil
+		},
+	})
+	now := time.Now()
+	machine.now = func() time.Time {
+		return now
+	}
+	ctx := context.Background()
+
+	require.NoError(t, machine.Handle(ctx, newTestEvent("/report")))
+	require.NoError(t, machine.Sweep(ctx))
+	assert.Empty(t, expired)
+
+	// the expired conversation is cleaned up when the next event comes
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, machine.Handle(ctx, newTestEvent("2024-05-01")))
+	assert.Equal(t, []Key{testKey}, expired)
+	session, err := machine.Session(testKey)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	// or by sweeping
+	require.NoError(t, machine.Handle(ctx, newTestEvent("/report")))
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, machine.Sweep(ctx))
+	assert.Equal(t, []Key{testKey, testKey}, expired)
+	session, err = machine.Session(testKey)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestKeyOf_CallbackQuery(t *testing.T) {
+	event := botgolang.Event{
+		Type: botgolang.CALLBACK_QUERY,
+		Payload: botgolang.EventPayload{
+			BaseEventPayload: botgolang.BaseEventPayload{From: botgolang.Contact{User: botgolang.User{ID: "user"}}},
+			CallbackMsg:      botgolang.BaseEventPayload{Chat: botgolang.Chat{ID: "chat"}},
+		},
+	}
+	assert.Equal(t, testKey, KeyOf(event))
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	updated := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	other := Key{ChatID: "chat", UserID: "other"}
+	require.NoError(t, store.Save(&Session{Key: testKey, State: "date", Data: map[string]string{"a": "b"}, Updated: updated}))
+	require.NoError(t, store.Save(&Session{Key: other, State: "reason", Updated: updated.Add(time.Hour)}))
+	require.NoError(t, store.Delete(Key{ChatID: "missing"}))
+
+	reopened, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	session, err := reopened.Load(testKey)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, State("date"), session.State)
+	assert.Equal(t, "b", session.Data["a"])
+	assert.True(t, updated.Equal(session.Updated))
+
+	expired, err := reopened.Expired(updated.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, testKey, expired[0].Key)
+
+	require.NoError(t, reopened.Delete(testKey))
+	reopened, err = NewFileStore(path)
+	require.NoError(t, err)
+	session, err = reopened.Load(testKey)
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
@@ -0,0 +1,169 @@
+package fsm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mail-ru-im/bot-golang/internal/atomicfile"
+)
+
+// SessionStore persists sessions of conversations
+type SessionStore interface {
+	// Load returns the session or nil if there is no session with the key
+	Load(key Key) (*Session, error)
+
+	// Save stores the session
+	Save(session *Session) error
+
+	// Delete removes the session, it is not an error if there is no session
+	Delete(key Key) error
+
+	// Expired returns sessions updated before the time
+	Expired(before time.Time) ([]*Session, error)
+}
+
+// MemoryStore keeps sessions in memory
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[Key]*Session
+}
+
+// NewMemoryStore returns new in-memory session store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[Key]*Session)}
+}
+
+func (s *MemoryStore) Load(key Key) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session, ok := s.sessions[key]; ok {
+		return session.clone(), nil
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) Save(session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.Key] = session.clone()
+	return nil
+}
+
+func (s *MemoryStore) Delete(key Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, key)
+	return nil
+}
+
+func (s *MemoryStore) Expired(before time.Time) ([]*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []*Session
+	for _, session := range s.sessions {
+		if session.Updated.Before(before) {
+			expired = append(expired, session.clone())
+		}
+	}
+	return expired, nil
+}
+
+// FileStore keeps sessions in memory and writes all of them to a JSON file on every change,
+// a failed write leaves the previous content of the file and the sessions unchanged.
+type FileStore struct {
+	path   string
+	memory *MemoryStore
+}
+
+// NewFileStore returns new session store writing to the file, sessions are loaded from it if it exists
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		path:   path,
+		memory: NewMemoryStore(),
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read sessions file: %w", err)
+	}
+
+	var sessions []*Session
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, fmt.Errorf("cannot parse sessions file: %w", err)
+	}
+	for _, session := range sessions {
+		store.memory.sessions[session.Key] = session
+	}
+
+	return store, nil
+}
+
+func (s *FileStore) Load(key Key) (*Session, error) {
+	return s.memory.Load(key)
+}
+
+func (s *FileStore) Save(session *Session) error {
+	s.memory.mu.Lock()
+	defer s.memory.mu.Unlock()
+
+	previous, existed := s.memory.sessions[session.Key]
+	s.memory.sessions[session.Key] = session.clone()
+	if err := s.write(); err != nil {
+		if existed {
+			s.memory.sessions[session.Key] = previous
+		} else {
+			delete(s.memory.sessions, session.Key)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) Delete(key Key) error {
+	s.memory.mu.Lock()
+	defer s.memory.mu.Unlock()
+
+	previous, existed := s.memory.sessions[key]
+	if !existed {
+		return nil
+	}
+
+	delete(s.memory.sessions, key)
+	if err := s.write(); err != nil {
+		s.memory.sessions[key] = previous
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) Expired(before time.Time) ([]*Session, error) {
+	return s.memory.Expired(before)
+}
+
+// write replaces the file with all sessions, must be called with the lock held
+func (s *FileStore) write() error {
+	sessions := make([]*Session, 0, len(s.memory.sessions))
+	for _, session := range s.memory.sessions {
+		sessions = append(sessions, session)
+	}
+
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		return fmt.Errorf("cannot marshal sessions: %w", err)
+	}
+
+	if err := atomicfile.Write(s.path, data); err != nil {
+		return fmt.Errorf("cannot write sessions file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package botgolang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// DownloadPath is the path of APICall made by DownloadFile and OpenFile to request the file content.
+// The call has fileId and offset params, its result has no body because the content is streamed.
+const DownloadPath = "/files/download"
+
+// APICall describes a request to API method passing through interceptors
+type APICall struct {
+	// Path of the API method, e.g. /messages/sendText
+	Path string
+
+	// Parameters of the request without the token, interceptors may modify them
+	Params url.Values
+
+	// File to upload, nil for requests without upload
+	File UploadFile
+
+	// Metadata of the uploaded file, nil for requests without upload
+	Upload *UploadInfo
+}
+
+// UploadInfo describes the uploaded file
+type UploadInfo struct {
+	// Name of the file
+	Name string
+
+	// Size of the file in bytes or -1 if it is unknown
+	Size int64
+}
+
+// APIResult is the result of API call
+type APIResult struct {
+	// Body of the response
+	Body []byte
+
+	// Decoded common part of the response, nil if it was not received or cannot be decoded
+	Response *Response
+
+	// response with the file content of DownloadPath call
+	download *http.Response
+}
+
+// Invoker performs API call
+type Invoker func(ctx context.Context, call *APICall) (*APIResult, error)
+
+// Interceptor wraps every API call made by the client.
+// It can modify the call before passing it to next, observe or replace the result,
+// or return without calling next to short-circuit the call.
+type Interceptor func(ctx context.Context, call *APICall, next Invoker) (*APIResult, error)
+
+func newAPICall(path string, params url.Values, file UploadFile) *APICall {
+	call := &APICall{
+		Path:   path,
+		Params: params,
+		File:   file,
+	}
+	if file != nil {
+		call.Upload = &UploadInfo{
+			Name: file.Name(),
+			Size: uploadSize(file),
+		}
+	}
+	return call
+}
+
+// invoker returns the chain of the client interceptors ending with the request itself
+func (c *Client) invoker() Invoker {
+	return c.chain(c.invoke)
+}
+
+// chain wraps the invoker with the client interceptors
+func (c *Client) chain(invoker Invoker) Invoker {
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors[i], invoker
+		invoker = func(ctx context.Context, call *APICall) (*APIResult, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+	return invoker
+}
+
+// invoke makes the request with retries
+func (c *Client) invoke(ctx context.Context, call *APICall) (*APIResult, error) {
+	body, err := c.doWithRetries(ctx, call.Path, call.Params, call.File)
+
+	result := &APIResult{Body: body}
+	if len(body) > 0 {
+		response := &Response{}
+		if json.Unmarshal(body, response) == nil {
+			result.Response = response
+		}
+	}
+
+	return result, err
+}
@@ -0,0 +1,84 @@
+package botgolang
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Interceptors_Order(t *testing.T) {
+	client := NewApiMockClient(t)
+
+	var calls []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, call *APICall, next Invoker) (*APIResult, error) {
+			calls = append(calls, name+" before")
+			result, err := next(ctx, call)
+			calls = append(calls, name+" after")
+			return result, err
+		}
+	}
+	client.interceptors = []Interceptor{record("first"), record("second")}
+
+	_, err := client.Do("/chats/getAdmins", url.Values{}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, calls)
+}
+
+func TestClient_Interceptors_ObserveAndModify(t *testing.T) {
+	client := NewApiMockClient(t)
+
+	var observed *APIResult
+	var upload *UploadInfo
+	client.interceptors = []Interceptor{
+		func(ctx context.Context, call *APICall, next Invoker) (*APIResult, error) {
+			assert.Empty(t, call.Params.Get("token"))
+			call.Params.Set("fileId", "injected")
+			upload = call.Upload
+
+			result, err := next(ctx, call)
+			assert.Empty(t, call.Params.Get("token"))
+			observed = result
+			return result, err
+		},
+	}
+
+	err := client.SendFileMessage(&Message{Chat: Chat{ID: "chat"}, FileID: "file"})
+	require.NoError(t, err)
+	require.NotNil(t, observed.Response)
+	assert.True(t, observed.Response.OK)
+	assert.Nil(t, upload)
+
+	_, err = client.Do("/messages/sendFile", url.Values{}, NewUploadFileFromReader("file.txt", strings.NewReader("content")))
+	require.NoError(t, err)
+	require.NotNil(t, upload)
+	assert.Equal(t, UploadInfo{Name: "file.txt", Size: 7}, *upload)
+}
+
+func TestClient_Interceptors_ShortCircuit(t *testing.T) {
+	client := NewApiMockClient(t)
+	client.baseURL = "http://127.0.0.1:0"
+
+	injected := errors.New("injected fault")
+	client.interceptors = []Interceptor{
+		func(ctx context.Context, call *APICall, next Invoker) (*APIResult, error) {
+			if call.Path == "/chats/setTitle" {
+				return nil, injected
+			}
+			return &APIResult{Body: []byte(`{"ok":true,"msgId":"fake"}`), Response: &Response{OK: true}}, nil
+		},
+	}
+
+	message := &Message{Chat: Chat{ID: "chat"}, Text: "text"}
+	require.NoError(t, client.SendTextMessage(message))
+	assert.Equal(t, "fake", message.ID)
+
+	err := client.SetChatTitle("chat", "title")
+	require.ErrorIs(t, err, injected)
+}
@@ -0,0 +1,96 @@
+// Package bottest provides a fake API for tests of packages built on top of the bot client
+package bottest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+// API serves events one by one and records requests made by handlers
+type API struct {
+	// Client of the API, it is bound to received events
+	Client *botgolang.Client
+
+	t      *testing.T
+	server *httptest.Server
+
+	mu       sync.Mutex
+	event    string
+	requests []Request
+}
+
+// Request is a request made to API, except getting events
+type Request struct {
+	// Path of the API method, e.g. /messages/sendText
+	Path string
+
+	// Params of the request without the token
+	Params url.Values
+}
+
+// NewAPI starts the fake API, it is stopped when the test finishes
+func NewAPI(t *testing.T) *API {
+	t.Helper()
+
+	api := &API{t: t}
+	api.server = httptest.NewServer(http.HandlerFunc(api.serve))
+	t.Cleanup(api.server.Close)
+	api.Client = botgolang.NewCustomClient(http.DefaultClient, api.server.URL, "test_token", nil)
+
+	return api
+}
+
+func (a *API) serve(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	if r.URL.Path != "/events/get" {
+		params.Del("token")
+		a.mu.Lock()
+		a.requests = append(a.requests, Request{Path: r.URL.Path, Params: params})
+		a.mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok":true,"msgId":"reply"}`))
+		return
+	}
+
+	a.mu.Lock()
+	event := a.event
+	if params.Get("pollTime") != "0" {
+		a.event = ""
+	}
+	a.mu.Unlock()
+
+	if params.Get("pollTime") == "0" || event == "" {
+		_, _ = w.Write([]byte(`{"ok":true,"events":[]}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"ok":true,"events":[` + event + `]}`))
+}
+
+// Receive returns the event decoded by the library, so it can be used to send replies
+func (a *API) Receive(event string) botgolang.Event {
+	a.t.Helper()
+
+	a.mu.Lock()
+	a.event = event
+	a.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan botgolang.Event)
+	go botgolang.NewUpdater(a.Client, 1, nil).RunUpdatesCheck(ctx, ch)
+
+	return <-ch
+}
+
+// Sent returns requests made to API so far
+func (a *API) Sent() []Request {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]Request(nil), a.requests...)
+}
@@ -0,0 +1,100 @@
+package botgolang
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogLevel is a severity of log record
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// LogFields are structured fields of log record
+type LogFields map[string]interface{}
+
+// Logger receives log records of the library.
+// Use NewLogrusLogger or NewSlogLogger to bind it to the logging library of your choice.
+type Logger interface {
+	// Enabled reports whether records of the level are logged,
+	// it allows skipping costly fields
+	Enabled(level LogLevel) bool
+
+	// Log writes the record
+	Log(level LogLevel, msg string, fields LogFields)
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Enabled(LogLevel) bool {
+	return false
+}
+
+func (noopLogger) Log(LogLevel, string, LogFields) {}
+
+type logrusLogger struct {
+	logger *logrus.Logger
+}
+
+// NewLogrusLogger returns Logger writing to the logrus logger, nil logger discards all records
+func NewLogrusLogger(logger *logrus.Logger) Logger {
+	if logger == nil {
+		return noopLogger{}
+	}
+	return &logrusLogger{logger: logger}
+}
+
+func (l *logrusLogger) Enabled(level LogLevel) bool {
+	return l.logger.IsLevelEnabled(logrusLevel(level))
+}
+
+func (l *logrusLogger) Log(level LogLevel, msg string, fields LogFields) {
+	l.logger.WithFields(logrus.Fields(fields)).Log(logrusLevel(level), msg)
+}
+
+func logrusLevel(level LogLevel) logrus.Level {
+	switch level {
+	case LogLevelDebug:
+		return logrus.DebugLevel
+	case LogLevelInfo:
+		return logrus.InfoLevel
+	case LogLevelWarn:
+		return logrus.WarnLevel
+	}
+	return logrus.ErrorLevel
+}
+
+const redacted = "REDACTED"
+
+// redactURL hides the token of the bot in the query of API url
+func redactURL(apiURL *url.URL) string {
+	query := apiURL.Query()
+	if !query.Has("token") {
+		return apiURL.String()
+	}
+
+	query.Set("token", redacted)
+	redactedURL := *apiURL
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+// redactError hides the token of the bot in the url of the failed request
+func redactError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	if parsed, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		urlErr.URL = redactURL(parsed)
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+//go:build go1.21
+
+package botgolang
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	logger := NewSlogLogger(slog.New(handler))
+	assert.False(t, logger.Enabled(LogLevelDebug))
+	assert.True(t, logger.Enabled(LogLevelError))
+
+	logger.Log(LogLevelDebug, "hidden", nil)
+	logger.Log(LogLevelError, "request error", LogFields{"path": "/self/get", "attempt": 2})
+	assert.Equal(t, "level=ERROR msg=\"request error\" attempt=2 path=/self/get\n", buf.String())
+}
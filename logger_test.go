@@ -0,0 +1,80 @@
+package botgolang
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogRecord struct {
+	level  LogLevel
+	msg    string
+	fields LogFields
+}
+
+type testLogger struct {
+	mu      sync.Mutex
+	records []testLogRecord
+}
+
+func (l *testLogger) Enabled(level LogLevel) bool {
+	return true
+}
+
+func (l *testLogger) Log(level LogLevel, msg string, fields LogFields) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, testLogRecord{level: level, msg: msg, fields: fields})
+}
+
+func TestClient_Do_LoggerRedactsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	logger := &testLogger{}
+	client := NewCustomClientWithLogger(http.DefaultClient, server.URL, "secret_token", logger)
+
+	_, err := client.Do("/chats/sendActions", url.Values{"chatId": {"chat"}}, nil)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, logger.records)
+	assert.Equal(t, LogLevelDebug, logger.records[0].level)
+	assert.Equal(t, "requesting api", logger.records[0].msg)
+	assert.Equal(t, server.URL+"/chats/sendActions?chatId=chat&token=REDACTED", logger.records[0].fields["api_url"])
+
+	client.baseURL = "http://127.0.0.1:0"
+	_, err = client.Do("/chats/sendActions", url.Values{}, nil)
+	require.ErrorIs(t, err, ErrTransport)
+	assert.NotContains(t, err.Error(), "secret_token")
+	for _, record := range logger.records {
+		assert.NotContains(t, fmt.Sprint(record.fields), "secret_token")
+	}
+}
+
+func TestNewLogrusLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logrusLogger := logrus.New()
+	logrusLogger.SetOutput(buf)
+	logrusLogger.SetLevel(logrus.InfoLevel)
+	logrusLogger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+
+	logger := NewLogrusLogger(logrusLogger)
+	assert.False(t, logger.Enabled(LogLevelDebug))
+	assert.True(t, logger.Enabled(LogLevelWarn))
+
+	logger.Log(LogLevelDebug, "hidden", nil)
+	logger.Log(LogLevelWarn, "request failed", LogFields{"path": "/self/get"})
+	assert.Equal(t, "level=warning msg=\"request failed\" path=/self/get\n", buf.String())
+
+	assert.False(t, NewLogrusLogger(nil).Enabled(LogLevelError))
+}
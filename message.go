@@ -1,6 +1,7 @@
 package botgolang
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 )
@@ -60,6 +61,9 @@ type Message struct {
 	// The parse mode (HTML/MarkdownV2)
 	ParseMode ParseMode `json:"parseMode"`
 
+	// Formatting of the text with ranges, an alternative to ParseMode
+	Format *Format `json:"format"`
+
 	// RequestID from library clients that is used in my-team logs
 	RequestID string `json:"requestID"`
 
@@ -116,6 +120,11 @@ func (m *Message) AttachInlineKeyboard(keyboard Keyboard) {
 // Send method sends your message.
 // Make sure you have Text or FileID in your message.
 func (m *Message) Send() error {
+	return m.SendWithContext(context.Background())
+}
+
+// SendWithContext is Send with a context
+func (m *Message) SendWithContext(ctx context.Context) error {
 	if m.client == nil {
 		return fmt.Errorf("client is not inited, create message with constructor NewMessage, NewTextMessage, etc")
 	}
@@ -127,71 +136,109 @@ func (m *Message) Send() error {
 	switch m.ContentType {
 	case Voice:
 		if m.FileID != "" {
-			return m.client.SendVoiceMessage(m)
+			return m.client.SendVoiceMessageWithContext(ctx, m)
 		}
 
 		if m.File != nil {
-			return m.client.UploadVoice(m)
+			return m.client.UploadVoiceWithContext(ctx, m)
 		}
 	case OtherFile:
 		if m.FileID != "" {
-			return m.client.SendFileMessage(m)
+			return m.client.SendFileMessageWithContext(ctx, m)
 		}
 
 		if m.File != nil {
-			return m.client.UploadFile(m)
+			return m.client.UploadFileWithContext(ctx, m)
 		}
 	case Text:
-		return m.client.SendTextMessage(m)
+		return m.client.SendTextMessageWithContext(ctx, m)
 	case Deeplink:
-		return m.client.SendTextWithDeeplinkMessage(m)
+		return m.client.SendTextWithDeeplinkMessageWithContext(ctx, m)
 	case Unknown:
 		// need to autodetect
 		if m.FileID != "" {
 			// voice message's fileID always starts with 'I'
 			if m.FileID[0] == voiceMessageLeadingRune {
-				return m.client.SendVoiceMessage(m)
+				return m.client.SendVoiceMessageWithContext(ctx, m)
 			}
-			return m.client.SendFileMessage(m)
+			return m.client.SendFileMessageWithContext(ctx, m)
 		}
 
 		if m.File != nil {
 			if voiceMessageSupportedExtensions[filepath.Ext(m.File.Name())] {
-				return m.client.UploadVoice(m)
+				return m.client.UploadVoiceWithContext(ctx, m)
 			}
-			return m.client.UploadFile(m)
+			return m.client.UploadFileWithContext(ctx, m)
 		}
 
 		if m.Text != "" {
-			return m.client.SendTextMessage(m)
+			return m.client.SendTextMessageWithContext(ctx, m)
 		}
 	}
 
 	return fmt.Errorf("cannot send message or file without data")
 }
 
+// SendSplit sends the message like Send, but a text longer than the limit of the bot is split into several messages.
+// The next messages are replies to the first one, the inline keyboard is attached to the last one.
+// It returns IDs of all sent messages, the ID of the message is set to the first one.
+func (m *Message) SendSplit() ([]string, error) {
+	return m.SendSplitWithContext(context.Background())
+}
+
+// SendSplitWithContext is SendSplit with a context
+func (m *Message) SendSplitWithContext(ctx context.Context) ([]string, error) {
+	if m.client == nil {
+		return nil, fmt.Errorf("client is not inited, create message with constructor NewMessage, NewTextMessage, etc")
+	}
+
+	if m.FileID != "" || m.File != nil || m.ContentType == Deeplink {
+		if err := m.SendWithContext(ctx); err != nil {
+			return nil, err
+		}
+		return []string{m.ID}, nil
+	}
+
+	return m.client.SendSplitTextMessageWithContext(ctx, m)
+}
+
 // Edit method edits your message.
 // Make sure you have ID in your message.
 func (m *Message) Edit() error {
+	return m.EditWithContext(context.Background())
+}
+
+// EditWithContext is Edit with a context
+func (m *Message) EditWithContext(ctx context.Context) error {
 	if m.ID == "" {
 		return fmt.Errorf("cannot edit message without id")
 	}
-	return m.client.EditMessage(m)
+	return m.client.EditMessageWithContext(ctx, m)
 }
 
 // Delete method deletes your message.
 // Make sure you have ID in your message.
 func (m *Message) Delete() error {
+	return m.DeleteWithContext(context.Background())
+}
+
+// DeleteWithContext is Delete with a context
+func (m *Message) DeleteWithContext(ctx context.Context) error {
 	if m.ID == "" {
 		return fmt.Errorf("cannot delete message without id")
 	}
 
-	return m.client.DeleteMessage(m)
+	return m.client.DeleteMessageWithContext(ctx, m)
 }
 
 // Reply method replies to the message.
 // Make sure you have ID in the message.
 func (m *Message) Reply(text string) error {
+	return m.ReplyWithContext(context.Background(), text)
+}
+
+// ReplyWithContext is Reply with a context
+func (m *Message) ReplyWithContext(ctx context.Context, text string) error {
 	if m.ID == "" {
 		return fmt.Errorf("cannot reply to message without id")
 	}
@@ -199,12 +246,17 @@ func (m *Message) Reply(text string) error {
 	m.ReplyMsgID = m.ID
 	m.Text = text
 
-	return m.client.SendTextMessage(m)
+	return m.client.SendTextMessageWithContext(ctx, m)
 }
 
 // Forward method forwards your message to chat.
 // Make sure you have ID in your message.
 func (m *Message) Forward(chatID string) error {
+	return m.ForwardWithContext(context.Background(), chatID)
+}
+
+// ForwardWithContext is Forward with a context
+func (m *Message) ForwardWithContext(ctx context.Context, chatID string) error {
 	if m.ID == "" {
 		return fmt.Errorf("cannot forward message without id")
 	}
@@ -213,25 +265,35 @@ func (m *Message) Forward(chatID string) error {
 	m.ForwardMsgID = m.ID
 	m.Chat.ID = chatID
 
-	return m.client.SendTextMessage(m)
+	return m.client.SendTextMessageWithContext(ctx, m)
 }
 
 // Pin message in chat
 // Make sure you are admin in this chat
 func (m *Message) Pin() error {
+	return m.PinWithContext(context.Background())
+}
+
+// PinWithContext is Pin with a context
+func (m *Message) PinWithContext(ctx context.Context) error {
 	if m.ID == "" {
 		return fmt.Errorf("cannot pin message without id")
 	}
 
-	return m.client.PinMessage(m)
+	return m.client.PinMessageWithContext(ctx, m)
 }
 
 // Unpin message in chat
 // Make sure you are admin in this chat
 func (m *Message) Unpin() error {
+	return m.UnpinWithContext(context.Background())
+}
+
+// UnpinWithContext is Unpin with a context
+func (m *Message) UnpinWithContext(ctx context.Context) error {
 	if m.ID == "" {
 		return fmt.Errorf("cannot unpin message without id")
 	}
 
-	return m.client.UnpinMessage(m)
+	return m.client.UnpinMessageWithContext(ctx, m)
 }
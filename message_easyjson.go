@@ -156,6 +156,16 @@ func easyjson4086215fDecodeGithubComMailRuImBotGolang1(in *jlexer.Lexer, out *Me
 			}
 		case "parseMode":
 			out.ParseMode = ParseMode(in.String())
+		case "format":
+			if in.IsNull() {
+				in.Skip()
+				out.Format = nil
+			} else {
+				if out.Format == nil {
+					out.Format = new(Format)
+				}
+				easyjson4086215fDecodeGithubComMailRuImBotGolang3(in, out.Format)
+			}
 		case "requestID":
 			out.RequestID = string(in.String())
 		case "deeplink":
@@ -242,6 +252,15 @@ func easyjson4086215fEncodeGithubComMailRuImBotGolang1(out *jwriter.Writer, in M
 		out.RawString(prefix)
 		out.String(string(in.ParseMode))
 	}
+	{
+		const prefix string = ",\"format\":"
+		out.RawString(prefix)
+		if in.Format == nil {
+			out.RawString("null")
+		} else {
+			easyjson4086215fEncodeGithubComMailRuImBotGolang3(out, *in.Format)
+		}
+	}
 	{
 		const prefix string = ",\"requestID\":"
 		out.RawString(prefix)
@@ -278,6 +297,660 @@ func (v *Message) UnmarshalJSON(data []byte) error {
 func (v *Message) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson4086215fDecodeGithubComMailRuImBotGolang1(l, v)
 }
+func easyjson4086215fDecodeGithubComMailRuImBotGolang3(in *jlexer.Lexer, out *Format) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "bold":
+			if in.IsNull() {
+				in.Skip()
+				out.Bold = nil
+			} else {
+				in.Delim('[')
+				if out.Bold == nil {
+					if !in.IsDelim(']') {
+						out.Bold = make([]FormatRange, 0, 4)
+					} else {
+						out.Bold = []FormatRange{}
+					}
+				} else {
+					out.Bold = (out.Bold)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v1)
+					out.Bold = append(out.Bold, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "italic":
+			if in.IsNull() {
+				in.Skip()
+				out.Italic = nil
+			} else {
+				in.Delim('[')
+				if out.Italic == nil {
+					if !in.IsDelim(']') {
+						out.Italic = make([]FormatRange, 0, 4)
+					} else {
+						out.Italic = []FormatRange{}
+					}
+				} else {
+					out.Italic = (out.Italic)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v2)
+					out.Italic = append(out.Italic, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "underline":
+			if in.IsNull() {
+				in.Skip()
+				out.Underline = nil
+			} else {
+				in.Delim('[')
+				if out.Underline == nil {
+					if !in.IsDelim(']') {
+						out.Underline = make([]FormatRange, 0, 4)
+					} else {
+						out.Underline = []FormatRange{}
+					}
+				} else {
+					out.Underline = (out.Underline)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v3)
+					out.Underline = append(out.Underline, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "strikethrough":
+			if in.IsNull() {
+				in.Skip()
+				out.Strikethrough = nil
+			} else {
+				in.Delim('[')
+				if out.Strikethrough == nil {
+					if !in.IsDelim(']') {
+						out.Strikethrough = make([]FormatRange, 0, 4)
+					} else {
+						out.Strikethrough = []FormatRange{}
+					}
+				} else {
+					out.Strikethrough = (out.Strikethrough)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v4)
+					out.Strikethrough = append(out.Strikethrough, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "link":
+			if in.IsNull() {
+				in.Skip()
+				out.Link = nil
+			} else {
+				in.Delim('[')
+				if out.Link == nil {
+					if !in.IsDelim(']') {
+						out.Link = make([]LinkRange, 0, 2)
+					} else {
+						out.Link = []LinkRange{}
+					}
+				} else {
+					out.Link = (out.Link)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 LinkRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang5(in, &v5)
+					out.Link = append(out.Link, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "mention":
+			if in.IsNull() {
+				in.Skip()
+				out.Mention = nil
+			} else {
+				in.Delim('[')
+				if out.Mention == nil {
+					if !in.IsDelim(']') {
+						out.Mention = make([]FormatRange, 0, 4)
+					} else {
+						out.Mention = []FormatRange{}
+					}
+				} else {
+					out.Mention = (out.Mention)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v6)
+					out.Mention = append(out.Mention, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "inline_code":
+			if in.IsNull() {
+				in.Skip()
+				out.InlineCode = nil
+			} else {
+				in.Delim('[')
+				if out.InlineCode == nil {
+					if !in.IsDelim(']') {
+						out.InlineCode = make([]FormatRange, 0, 4)
+					} else {
+						out.InlineCode = []FormatRange{}
+					}
+				} else {
+					out.InlineCode = (out.InlineCode)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v7)
+					out.InlineCode = append(out.InlineCode, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "pre":
+			if in.IsNull() {
+				in.Skip()
+				out.Pre = nil
+			} else {
+				in.Delim('[')
+				if out.Pre == nil {
+					if !in.IsDelim(']') {
+						out.Pre = make([]PreRange, 0, 2)
+					} else {
+						out.Pre = []PreRange{}
+					}
+				} else {
+					out.Pre = (out.Pre)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 PreRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang6(in, &v8)
+					out.Pre = append(out.Pre, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "ordered_list":
+			if in.IsNull() {
+				in.Skip()
+				out.OrderedList = nil
+			} else {
+				in.Delim('[')
+				if out.OrderedList == nil {
+					if !in.IsDelim(']') {
+						out.OrderedList = make([]FormatRange, 0, 4)
+					} else {
+						out.OrderedList = []FormatRange{}
+					}
+				} else {
+					out.OrderedList = (out.OrderedList)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v9 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v9)
+					out.OrderedList = append(out.OrderedList, v9)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "unordered_list":
+			if in.IsNull() {
+				in.Skip()
+				out.UnorderedList = nil
+			} else {
+				in.Delim('[')
+				if out.UnorderedList == nil {
+					if !in.IsDelim(']') {
+						out.UnorderedList = make([]FormatRange, 0, 4)
+					} else {
+						out.UnorderedList = []FormatRange{}
+					}
+				} else {
+					out.UnorderedList = (out.UnorderedList)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v10)
+					out.UnorderedList = append(out.UnorderedList, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "quote":
+			if in.IsNull() {
+				in.Skip()
+				out.Quote = nil
+			} else {
+				in.Delim('[')
+				if out.Quote == nil {
+					if !in.IsDelim(']') {
+						out.Quote = make([]FormatRange, 0, 4)
+					} else {
+						out.Quote = []FormatRange{}
+					}
+				} else {
+					out.Quote = (out.Quote)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 FormatRange
+					easyjson4086215fDecodeGithubComMailRuImBotGolang4(in, &v11)
+					out.Quote = append(out.Quote, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComMailRuImBotGolang3(out *jwriter.Writer, in Format) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.Bold) != 0 {
+		const prefix string = ",\"bold\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v12, v13 := range in.Bold {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v13)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Italic) != 0 {
+		const prefix string = ",\"italic\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.Italic {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v15)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Underline) != 0 {
+		const prefix string = ",\"underline\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v16, v17 := range in.Underline {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v17)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Strikethrough) != 0 {
+		const prefix string = ",\"strikethrough\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v18, v19 := range in.Strikethrough {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v19)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Link) != 0 {
+		const prefix string = ",\"link\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.Link {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang5(out, v21)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Mention) != 0 {
+		const prefix string = ",\"mention\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v22, v23 := range in.Mention {
+				if v22 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v23)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.InlineCode) != 0 {
+		const prefix string = ",\"inline_code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v24, v25 := range in.InlineCode {
+				if v24 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v25)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Pre) != 0 {
+		const prefix string = ",\"pre\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v26, v27 := range in.Pre {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang6(out, v27)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.OrderedList) != 0 {
+		const prefix string = ",\"ordered_list\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v28, v29 := range in.OrderedList {
+				if v28 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v29)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.UnorderedList) != 0 {
+		const prefix string = ",\"unordered_list\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v30, v31 := range in.UnorderedList {
+				if v30 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v31)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Quote) != 0 {
+		const prefix string = ",\"quote\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v32, v33 := range in.Quote {
+				if v32 > 0 {
+					out.RawByte(',')
+				}
+				easyjson4086215fEncodeGithubComMailRuImBotGolang4(out, v33)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson4086215fDecodeGithubComMailRuImBotGolang6(in *jlexer.Lexer, out *PreRange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "offset":
+			out.Offset = int(in.Int())
+		case "length":
+			out.Length = int(in.Int())
+		case "code_language":
+			out.CodeLanguage = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComMailRuImBotGolang6(out *jwriter.Writer, in PreRange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Offset))
+	}
+	{
+		const prefix string = ",\"length\":"
+		out.RawString(prefix)
+		out.Int(int(in.Length))
+	}
+	if in.CodeLanguage != "" {
+		const prefix string = ",\"code_language\":"
+		out.RawString(prefix)
+		out.String(string(in.CodeLanguage))
+	}
+	out.RawByte('}')
+}
+func easyjson4086215fDecodeGithubComMailRuImBotGolang5(in *jlexer.Lexer, out *LinkRange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "offset":
+			out.Offset = int(in.Int())
+		case "length":
+			out.Length = int(in.Int())
+		case "url":
+			out.URL = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComMailRuImBotGolang5(out *jwriter.Writer, in LinkRange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Offset))
+	}
+	{
+		const prefix string = ",\"length\":"
+		out.RawString(prefix)
+		out.Int(int(in.Length))
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	out.RawByte('}')
+}
+func easyjson4086215fDecodeGithubComMailRuImBotGolang4(in *jlexer.Lexer, out *FormatRange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "offset":
+			out.Offset = int(in.Int())
+		case "length":
+			out.Length = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComMailRuImBotGolang4(out *jwriter.Writer, in FormatRange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Offset))
+	}
+	{
+		const prefix string = ",\"length\":"
+		out.RawString(prefix)
+		out.Int(int(in.Length))
+	}
+	out.RawByte('}')
+}
 func easyjson4086215fDecodeGithubComMailRuImBotGolang2(in *jlexer.Lexer, out *Keyboard) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -313,30 +986,30 @@ func easyjson4086215fDecodeGithubComMailRuImBotGolang2(in *jlexer.Lexer, out *Ke
 					out.Rows = (out.Rows)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 []Button
+					var v34 []Button
 					if in.IsNull() {
 						in.Skip()
-						v1 = nil
+						v34 = nil
 					} else {
 						in.Delim('[')
-						if v1 == nil {
+						if v34 == nil {
 							if !in.IsDelim(']') {
-								v1 = make([]Button, 0, 1)
+								v34 = make([]Button, 0, 1)
 							} else {
-								v1 = []Button{}
+								v34 = []Button{}
 							}
 						} else {
-							v1 = (v1)[:0]
+							v34 = (v34)[:0]
 						}
 						for !in.IsDelim(']') {
-							var v2 Button
-							(v2).UnmarshalEasyJSON(in)
-							v1 = append(v1, v2)
+							var v35 Button
+							(v35).UnmarshalEasyJSON(in)
+							v34 = append(v34, v35)
 							in.WantComma()
 						}
 						in.Delim(']')
 					}
-					out.Rows = append(out.Rows, v1)
+					out.Rows = append(out.Rows, v34)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -362,19 +1035,19 @@ func easyjson4086215fEncodeGithubComMailRuImBotGolang2(out *jwriter.Writer, in K
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v3, v4 := range in.Rows {
-				if v3 > 0 {
+			for v36, v37 := range in.Rows {
+				if v36 > 0 {
 					out.RawByte(',')
 				}
-				if v4 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+				if v37 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 					out.RawString("null")
 				} else {
 					out.RawByte('[')
-					for v5, v6 := range v4 {
-						if v5 > 0 {
+					for v38, v39 := range v37 {
+						if v38 > 0 {
 							out.RawByte(',')
 						}
-						(v6).MarshalEasyJSON(out)
+						(v39).MarshalEasyJSON(out)
 					}
 					out.RawByte(']')
 				}
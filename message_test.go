@@ -1,6 +1,7 @@
 package botgolang
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -372,3 +373,20 @@ func TestMessage_Send_TextMessage(t *testing.T) {
 	err := msg.Send()
 	assert.NoError(t, err)
 }
+
+func TestMessage_SendWithContext_Canceled(t *testing.T) {
+	client := NewApiMockClient(t)
+
+	msg := &Message{
+		client:      &client,
+		Chat:        Chat{ID: "chat123"},
+		Text:        "test message",
+		ContentType: Text,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := msg.SendWithContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,100 @@
+package botgolang
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Names of the metrics reported by the library
+const (
+	// Counter of API calls with labels method and result
+	MetricAPIRequests = "botgolang_api_requests_total"
+
+	// Histogram of API call duration in seconds including retries, with label method
+	MetricAPIRequestDuration = "botgolang_api_request_duration_seconds"
+
+	// Counter of retried API requests with label method
+	MetricAPIRetries = "botgolang_api_retries_total"
+
+	// Counter of received events with label type
+	MetricUpdaterEvents = "botgolang_updater_events_total"
+
+	// Counter of failed requests for events
+	MetricUpdaterErrors = "botgolang_updater_errors_total"
+
+	// Gauge of seconds between the timestamp of the last received event and the moment it was received
+	MetricUpdaterLag = "botgolang_updater_lag_seconds"
+
+	// Gauge of fetched events which are not delivered to the updates channel yet
+	MetricUpdaterPendingEvents = "botgolang_updater_pending_events"
+
+	// Histogram of seconds the updater was blocked delivering an event to the updates channel
+	MetricUpdaterChannelWait = "botgolang_updater_channel_wait_seconds"
+)
+
+// Results of API calls used as result label of MetricAPIRequests
+const (
+	ResultOK             = "ok"
+	ResultAPIError       = "api_error"
+	ResultTransportError = "transport_error"
+	ResultDecodeError    = "decode_error"
+	ResultCanceled       = "canceled"
+	ResultError          = "error"
+)
+
+// Metrics receives measurements of the library.
+// Implement it to bind the library to any metrics registry
+// or use the registry from metrics subpackage with Prometheus text exposition.
+type Metrics interface {
+	// AddCounter increases the counter by value
+	AddCounter(name string, value float64, labels map[string]string)
+
+	// ObserveHistogram records the value in the histogram
+	ObserveHistogram(name string, value float64, labels map[string]string)
+
+	// SetGauge sets the gauge to value
+	SetGauge(name string, value float64, labels map[string]string)
+}
+
+// callResult returns the result label for the error of API call
+func callResult(err error) string {
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		return ResultOK
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ResultCanceled
+	case errors.As(err, &apiErr):
+		return ResultAPIError
+	case errors.Is(err, ErrTransport):
+		return ResultTransportError
+	case errors.Is(err, ErrDecode):
+		return ResultDecodeError
+	}
+	return ResultError
+}
+
+func (c *Client) observeCall(path string, start time.Time, err error) {
+	if c.metrics == nil {
+		return
+	}
+
+	c.metrics.AddCounter(MetricAPIRequests, 1, map[string]string{
+		"method": path,
+		"result": callResult(err),
+	})
+	c.metrics.ObserveHistogram(MetricAPIRequestDuration, time.Since(start).Seconds(), map[string]string{
+		"method": path,
+	})
+}
+
+func (c *Client) observeRetry(path string) {
+	if c.metrics == nil {
+		return
+	}
+
+	c.metrics.AddCounter(MetricAPIRetries, 1, map[string]string{
+		"method": path,
+	})
+}
@@ -0,0 +1,226 @@
+// Package metrics provides an in-memory registry for bot metrics
+// and an HTTP handler exposing them in Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are upper bounds of histogram buckets in seconds suited for API latency
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+const (
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+// Registry stores metrics in memory, it implements botgolang.Metrics.
+// Registry is safe for concurrent use.
+type Registry struct {
+	buckets []float64
+
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+type family struct {
+	kind   string
+	series map[string]*series
+}
+
+type series struct {
+	labels  string
+	value   float64
+	sum     float64
+	count   uint64
+	buckets []uint64
+}
+
+// NewRegistry returns new registry, histograms use the given bucket bounds or DefaultBuckets if none are given
+func NewRegistry(buckets ...float64) *Registry {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+
+	return &Registry{
+		buckets:  bounds,
+		families: make(map[string]*family),
+	}
+}
+
+// AddCounter increases the counter by value
+func (r *Registry) AddCounter(name string, value float64, labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s := r.series(name, kindCounter, labels); s != nil {
+		s.value += value
+	}
+}
+
+// SetGauge sets the gauge to value
+func (r *Registry) SetGauge(name string, value float64, labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s := r.series(name, kindGauge, labels); s != nil {
+		s.value = value
+	}
+}
+
+// ObserveHistogram records the value in the histogram
+func (r *Registry) ObserveHistogram(name string, value float64, labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.series(name, kindHistogram, labels)
+	if s == nil {
+		return
+	}
+	if s.buckets == nil {
+		s.buckets = make([]uint64, len(r.buckets))
+	}
+
+	s.sum += value
+	s.count++
+	for i, bound := range r.buckets {
+		if value <= bound {
+			s.buckets[i]++
+		}
+	}
+}
+
+// series returns the series of the metric, creating it if needed.
+// It returns nil if the metric is already registered with another kind.
+// Must be called with the lock held.
+func (r *Registry) series(name, kind string, labels map[string]string) *series {
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{kind: kind, series: make(map[string]*series)}
+		r.families[name] = f
+	}
+	if f.kind != kind {
+		return nil
+	}
+
+	key := formatLabels(labels)
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: key}
+		f.series[key] = s
+	}
+	return s
+}
+
+// ServeHTTP writes all metrics in Prometheus text exposition format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	buf := bufio.NewWriter(w)
+	r.write(buf)
+	_ = buf.Flush()
+}
+
+// Handler returns HTTP handler exposing the metrics
+func (r *Registry) Handler() http.Handler {
+	return r
+}
+
+func (r *Registry) write(w *bufio.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := r.families[name]
+		fmt.Fprintf(w, "# TYPE %s %s\n", name, f.kind)
+
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s := f.series[key]
+			if f.kind != kindHistogram {
+				fmt.Fprintf(w, "%s%s %s\n", name, braces(s.labels), formatValue(s.value))
+				continue
+			}
+
+			for i, bound := range r.buckets {
+				le := `le="` + formatValue(bound) + `"`
+				fmt.Fprintf(w, "%s_bucket%s %d\n", name, braces(joinLabels(s.labels, le)), s.buckets[i])
+			}
+			fmt.Fprintf(w, "%s_bucket%s %d\n", name, braces(joinLabels(s.labels, `le="+Inf"`)), s.count)
+			fmt.Fprintf(w, "%s_sum%s %s\n", name, braces(s.labels), formatValue(s.sum))
+			fmt.Fprintf(w, "%s_count%s %d\n", name, braces(s.labels), s.count)
+		}
+	}
+}
+
+// formatLabels returns labels sorted by name in exposition format without braces
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, name+`="`+escapeLabel(labels[name])+`"`)
+	}
+	return strings.Join(pairs, ",")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func joinLabels(labels, label string) string {
+	if labels == "" {
+		return label
+	}
+	return labels + "," + label
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	registry := NewRegistry(0.1, 1)
+
+	registry.AddCounter("requests_total", 1, map[string]string{"method": "/messages/sendText", "result": "ok"})
+	registry.AddCounter("requests_total", 2, map[string]string{"result": "ok", "method": "/messages/sendText"})
+	registry.AddCounter("requests_total", 1, map[string]string{"method": "/chats/get", "result": `"api"\error`})
+	registry.SetGauge("lag_seconds", 3, nil)
+	registry.SetGauge("lag_seconds", 1.5, nil)
+	registry.ObserveHistogram("duration_seconds", 0.05, map[string]string{"method": "/self/get"})
+	registry.ObserveHistogram("duration_seconds", 0.5, map[string]string{"method": "/self/get"})
+	registry.ObserveHistogram("duration_seconds", 5, map[string]string{"method": "/self/get"})
+
+	// the metric is registered as a counter already
+	registry.SetGauge("requests_total", 10, nil)
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `# TYPE duration_seconds histogram
+duration_seconds_bucket{method="/self/get",le="0.1"} 1
+duration_seconds_bucket{method="/self/get",le="1"} 2
+duration_seconds_bucket{method="/self/get",le="+Inf"} 3
+duration_seconds_sum{method="/self/get"} 5.55
+duration_seconds_count{method="/self/get"} 3
+# TYPE lag_seconds gauge
+lag_seconds 1.5
+# TYPE requests_total counter
+requests_total{method="/chats/get",result="\"api\"\\error"} 1
+requests_total{method="/messages/sendText",result="ok"} 3
+`, string(body))
+}
@@ -0,0 +1,132 @@
+package botgolang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics struct {
+	mu         sync.Mutex
+	counters   map[string]float64
+	gauges     map[string]float64
+	histograms map[string]int
+}
+
+func newTestMetrics() *testMetrics {
+	return &testMetrics{
+		counters:   make(map[string]float64),
+		gauges:     make(map[string]float64),
+		histograms: make(map[string]int),
+	}
+}
+
+func metricKey(name string, labels map[string]string) string {
+	key := name
+	for _, label := range []string{"method", "result", "type"} {
+		if value, ok := labels[label]; ok {
+			key += " " + label + "=" + value
+		}
+	}
+	return key
+}
+
+func (m *testMetrics) AddCounter(name string, value float64, labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[metricKey(name, labels)] += value
+}
+
+func (m *testMetrics) ObserveHistogram(name string, value float64, labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.histograms[metricKey(name, labels)]++
+}
+
+func (m *testMetrics) SetGauge(name string, value float64, labels map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gauges[metricKey(name, labels)] = value
+}
+
+func TestClient_Do_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chats/get":
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Chat not found"}`))
+		case "/chats/getAdmins":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	metrics := newTestMetrics()
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	client.retryPolicy = testRetryPolicy()
+	client.metrics = metrics
+
+	_, err := client.Do("/chats/sendActions", url.Values{}, nil)
+	require.NoError(t, err)
+	_, err = client.Do("/chats/get", url.Values{}, nil)
+	require.ErrorIs(t, err, ErrChatNotFound)
+	_, err = client.Do("/chats/getAdmins", url.Values{}, nil)
+	require.Error(t, err)
+
+	assert.Equal(t, map[string]float64{
+		MetricAPIRequests + " method=/chats/sendActions result=ok":      1,
+		MetricAPIRequests + " method=/chats/get result=api_error":       1,
+		MetricAPIRequests + " method=/chats/getAdmins result=api_error": 1,
+		MetricAPIRetries + " method=/chats/getAdmins":                   2,
+	}, metrics.counters)
+	assert.Equal(t, 1, metrics.histograms[MetricAPIRequestDuration+" method=/chats/getAdmins"])
+}
+
+func TestUpdater_RunUpdatesCheck_Metrics(t *testing.T) {
+	timestamp := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the initial request skips old events
+		if r.URL.Query().Get("pollTime") == "0" || r.URL.Query().Get("lastEventId") != "0" {
+			time.Sleep(10 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"ok":true,"events":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"events":[
+			{"eventId":1,"type":"newMessage","payload":{"msgId":"1","timestamp":` + timestamp + `}},
+			{"eventId":2,"type":"deletedMessage","payload":{"msgId":"2","timestamp":` + timestamp + `}}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	metrics := newTestMetrics()
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	client.metrics = metrics
+	updater := NewUpdater(client, 1, &logrus.Logger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan Event)
+	go updater.RunUpdatesCheck(ctx, ch)
+
+	<-ch
+	<-ch
+	cancel()
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	assert.Equal(t, float64(1), metrics.counters[MetricUpdaterEvents+" type=newMessage"])
+	assert.Equal(t, float64(1), metrics.counters[MetricUpdaterEvents+" type=deletedMessage"])
+	assert.GreaterOrEqual(t, metrics.gauges[MetricUpdaterLag], float64(59))
+	assert.GreaterOrEqual(t, metrics.histograms[MetricUpdaterChannelWait], 1)
+}
@@ -0,0 +1,101 @@
+package botgolang
+
+import "context"
+
+// Middleware wraps a handler to add behaviour before and after it
+type Middleware func(next Handler) Handler
+
+// Chain wraps the handler with the middlewares, the first middleware is the outermost
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+type botContextKey struct{}
+
+type eventContextKey struct{}
+
+type loggerContextKey struct{}
+
+// BotFromContext returns the bot handling the event, it is set by Bot.Dispatch
+func BotFromContext(ctx context.Context) *Bot {
+	bot, _ := ctx.Value(botContextKey{}).(*Bot)
+	return bot
+}
+
+// EventFromContext returns the handled event, it is set by Bot.Dispatch
+func EventFromContext(ctx context.Context) (Event, bool) {
+	event, ok := ctx.Value(eventContextKey{}).(Event)
+	return event, ok
+}
+
+// LoggerFromContext returns the logger with fields of the handled event, it is set by Bot.Dispatch.
+// It returns a logger discarding records if the context has no logger.
+func LoggerFromContext(ctx context.Context) Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(Logger); ok {
+		return logger
+	}
+	return noopLogger{}
+}
+
+// ContextWithBot returns the context carrying the bot, e.g. to use middlewares without Bot.Dispatch
+func ContextWithBot(ctx context.Context, bot *Bot) context.Context {
+	return context.WithValue(ctx, botContextKey{}, bot)
+}
+
+// ContextWithLogger returns the context carrying the logger
+func ContextWithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// eventContext returns the context of handling the event by the bot
+func (b *Bot) eventContext(ctx context.Context, event Event) context.Context {
+	fields := LogFields{
+		"event_id": event.EventID,
+		"chat_id":  event.ChatID(),
+	}
+	if userID := event.Payload.From.User.ID; userID != "" {
+		fields["user_id"] = userID
+	}
+
+	ctx = ContextWithBot(ctx, b)
+	ctx = context.WithValue(ctx, eventContextKey{}, event)
+	return ContextWithLogger(ctx, WithLogFields(b.logger, fields))
+}
+
+type fieldsLogger struct {
+	logger Logger
+	fields LogFields
+}
+
+// WithLogFields returns the logger adding the fields to every record
+func WithLogFields(logger Logger, fields LogFields) Logger {
+	if parent, ok := logger.(*fieldsLogger); ok {
+		merged := make(LogFields, len(parent.fields)+len(fields))
+		for key, value := range parent.fields {
+			merged[key] = value
+		}
+		for key, value := range fields {
+			merged[key] = value
+		}
+		return &fieldsLogger{logger: parent.logger, fields: merged}
+	}
+	return &fieldsLogger{logger: logger, fields: fields}
+}
+
+func (l *fieldsLogger) Enabled(level LogLevel) bool {
+	return l.logger.Enabled(level)
+}
+
+func (l *fieldsLogger) Log(level LogLevel, msg string, fields LogFields) {
+	merged := make(LogFields, len(l.fields)+len(fields))
+	for key, value := range l.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	l.logger.Log(level, msg, merged)
+}
@@ -0,0 +1,137 @@
+// Package middleware provides middlewares for event handlers:
+// panic recovery, logging, admin-only guard, timeouts and timing.
+//
+//	handler := botgolang.Chain(router,
+//		middleware.Recover(),
+//		middleware.Logging(),
+//		middleware.Timeout(30*time.Second),
+//	)
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+// MetricHandlerDuration is a histogram of event handling duration in seconds with labels type and result
+const MetricHandlerDuration = "botgolang_handler_duration_seconds"
+
+// Recover turns a panic of the handler into an error wrapping botgolang.ErrHandlerPanic and logs it with the stack
+func Recover() botgolang.Middleware {
+	return func(next botgolang.Handler) botgolang.Handler {
+		return botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%w: %v", botgolang.ErrHandlerPanic, r)
+					botgolang.LoggerFromContext(ctx).Log(botgolang.LogLevelError, "handler panicked", botgolang.LogFields{
+						"err":   err,
+						"stack": string(debug.Stack()),
+					})
+				}
+			}()
+
+			return next.Handle(ctx, event)
+		})
+	}
+}
+
+// Logging logs every event with its type and the result of handling
+func Logging() botgolang.Middleware {
+	return func(next botgolang.Handler) botgolang.Handler {
+		return botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+			logger := botgolang.LoggerFromContext(ctx)
+			logger.Log(botgolang.LogLevelDebug, "handling event", botgolang.LogFields{
+				"type": event.Type,
+			})
+
+			start := time.Now()
+			err := next.Handle(ctx, event)
+			fields := botgolang.LogFields{
+				"type":     event.Type,
+				"duration": time.Since(start),
+			}
+
+			if err != nil {
+				fields["err"] = err
+				logger.Log(botgolang.LogLevelError, "cannot handle event", fields)
+				return err
+			}
+
+			logger.Log(botgolang.LogLevelInfo, "event handled", fields)
+			return nil
+		})
+	}
+}
+
+// AdminOnly passes events only from admins of the chat, events in private chats are always passed.
+// Other events are passed to denied handler if it is not nil.
+// The admins are requested with the bot from the context, see botgolang.BotFromContext.
+func AdminOnly(denied botgolang.Handler) botgolang.Middleware {
+	return func(next botgolang.Handler) botgolang.Handler {
+		return botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+			// the chat of callback queries is in the message with the button
+			if event.Payload.Chat.Type == botgolang.Private || event.Payload.CallbackMsg.Chat.Type == botgolang.Private {
+				return next.Handle(ctx, event)
+			}
+
+			bot := botgolang.BotFromContext(ctx)
+			if bot == nil {
+				return fmt.Errorf("admin only: no bot in the context")
+			}
+
+			admins, err := bot.GetChatAdminsWithContext(ctx, event.ChatID())
+			if err != nil {
+				return fmt.Errorf("admin only: %w", err)
+			}
+
+			userID := event.Payload.From.User.ID
+			for _, admin := range admins {
+				if admin.User.ID == userID {
+					return next.Handle(ctx, event)
+				}
+			}
+
+			if denied != nil {
+				return denied.Handle(ctx, event)
+			}
+			return nil
+		})
+	}
+}
+
+// Timeout limits the time of handling with the context deadline
+func Timeout(timeout time.Duration) botgolang.Middleware {
+	return func(next botgolang.Handler) botgolang.Handler {
+		return botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return next.Handle(ctx, event)
+		})
+	}
+}
+
+// Timing records the duration of handling to MetricHandlerDuration
+func Timing(metrics botgolang.Metrics) botgolang.Middleware {
+	return func(next botgolang.Handler) botgolang.Handler {
+		return botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+			start := time.Now()
+			err := next.Handle(ctx, event)
+
+			result := botgolang.ResultOK
+			if err != nil {
+				result = botgolang.ResultError
+			}
+			metrics.ObserveHistogram(MetricHandlerDuration, time.Since(start).Seconds(), map[string]string{
+				"type":   string(event.Type),
+				"result": result,
+			})
+
+			return err
+		})
+	}
+}
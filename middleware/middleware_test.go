@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+	"github.com/mail-ru-im/bot-golang/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogger struct {
+	mu       sync.Mutex
+	messages []string
+	fields   []botgolang.LogFields
+}
+
+func (l *testLogger) Enabled(level botgolang.LogLevel) bool {
+	return true
+}
+
+func (l *testLogger) Log(level botgolang.LogLevel, msg string, fields botgolang.LogFields) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, msg)
+	l.fields = append(l.fields, fields)
+}
+
+func TestRecover(t *testing.T) {
+	logger := &testLogger{}
+	ctx := botgolang.ContextWithLogger(context.Background(), logger)
+
+	handler := botgolang.Chain(botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+		panic("boom")
+	}), Recover())
+
+	err := handler.Handle(ctx, botgolang.Event{})
+	require.ErrorIs(t, err, botgolang.ErrHandlerPanic)
+	assert.Contains(t, err.Error(), "boom")
+	assert.Equal(t, []string{"handler panicked"}, logger.messages)
+	assert.Contains(t, logger.fields[0]["stack"], "middleware.go")
+}
+
+func TestLogging(t *testing.T) {
+	logger := &testLogger{}
+	ctx := botgolang.ContextWithLogger(context.Background(), logger)
+	failure := errors.New("failure")
+
+	handler := botgolang.Chain(botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+		if event.EventID == 2 {
+			return failure
+		}
+		return nil
+	}), Logging())
+
+	require.NoError(t, handler.Handle(ctx, botgolang.Event{EventID: 1, Type: botgolang.NEW_MESSAGE}))
+	require.ErrorIs(t, handler.Handle(ctx, botgolang.Event{EventID: 2, Type: botgolang.NEW_MESSAGE}), failure)
+
+	assert.Equal(t, []string{"handling event", "event handled", "handling event", "cannot handle event"}, logger.messages)
+	assert.Equal(t, failure, logger.fields[3]["err"])
+	assert.Contains(t, logger.fields[1], "duration")
+}
+
+func TestTimeout(t *testing.T) {
+	handler := botgolang.Chain(botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), Timeout(10*time.Millisecond))
+
+	err := handler.Handle(context.Background(), botgolang.Event{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTiming(t *testing.T) {
+	registry := metrics.NewRegistry(1)
+	handler := botgolang.Chain(botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+		return nil
+	}), Timing(registry))
+
+	require.NoError(t, handler.Handle(context.Background(), botgolang.Event{Type: botgolang.NEW_MESSAGE}))
+
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, recorder.Body.String(), MetricHandlerDuration+`_count{result="ok",type="newMessage"} 1`)
+}
+
+func TestAdminOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/self/get":
+			_, _ = w.Write([]byte(`{"ok":true,"userId":"bot","nick":"bot"}`))
+		case "/chats/getAdmins":
+			_, _ = w.Write([]byte(`{"ok":true,"admins":[{"userId":"admin","creator":true}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	bot, err := botgolang.NewBot("test_token", botgolang.BotApiURL(server.URL), botgolang.BotLogger(&testLogger{}))
+	require.NoError(t, err)
+	ctx := botgolang.ContextWithBot(context.Background(), bot)
+
+	var handled, denied []string
+	handler := botgolang.Chain(botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+		handled = append(handled, event.Payload.From.User.ID)
+		return nil
+	}), AdminOnly(botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+		denied = append(denied, event.Payload.From.User.ID)
+		return nil
+	})))
+
+	event := func(chatType, userID string) botgolang.Event {
+		return botgolang.Event{Payload: botgolang.EventPayload{BaseEventPayload: botgolang.BaseEventPayload{
+			Chat: botgolang.Chat{ID: "chat", Type: chatType},
+			From: botgolang.Contact{User: botgolang.User{ID: userID}},
+		}}}
+	}
+
+	require.NoError(t, handler.Handle(ctx, event(botgolang.Group, "admin")))
+	require.NoError(t, handler.Handle(ctx, event(botgolang.Group, "user")))
+	require.NoError(t, handler.Handle(ctx, event(botgolang.Private, "user")))
+
+	callback := func(userID string) botgolang.Event {
+		return botgolang.Event{Type: botgolang.CALLBACK_QUERY, Payload: botgolang.EventPayload{
+			CallbackMsg: botgolang.BaseEventPayload{Chat: botgolang.Chat{ID: "chat", Type: botgolang.Group}},
+			BaseEventPayload: botgolang.BaseEventPayload{
+				From: botgolang.Contact{User: botgolang.User{ID: userID}},
+			},
+		}}
+	}
+	require.NoError(t, handler.Handle(ctx, callback("admin")))
+	require.NoError(t, handler.Handle(ctx, callback("stranger")))
+
+	assert.Equal(t, []string{"admin", "user", "admin"}, handled)
+	assert.Equal(t, []string{"user", "stranger"}, denied)
+
+	err = handler.Handle(context.Background(), event(botgolang.Group, "admin"))
+	require.Error(t, err)
+}
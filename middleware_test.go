@@ -0,0 +1,91 @@
+package botgolang
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(ctx context.Context, event Event) error {
+				calls = append(calls, name+" before")
+				err := next.Handle(ctx, event)
+				calls = append(calls, name+" after")
+				return err
+			})
+		}
+	}
+
+	handler := Chain(HandlerFunc(func(ctx context.Context, event Event) error {
+		calls = append(calls, "handler")
+		return nil
+	}), record("first"), record("second"))
+
+	require.NoError(t, handler.Handle(context.Background(), Event{}))
+	assert.Equal(t, []string{"first before", "second before", "handler", "second after", "first after"}, calls)
+}
+
+func TestWithLogFields(t *testing.T) {
+	logger := &testLogger{}
+	child := WithLogFields(WithLogFields(logger, LogFields{"chat_id": "chat", "user_id": "user"}), LogFields{"user_id": "other"})
+
+	child.Log(LogLevelInfo, "message", LogFields{"event_id": 1})
+
+	require.Len(t, logger.records, 1)
+	assert.Equal(t, LogFields{"chat_id": "chat", "user_id": "other", "event_id": 1}, logger.records[0].fields)
+}
+
+func TestBot_Dispatch_Context(t *testing.T) {
+	server := newUpdatesTestServer(t)
+	updater := newUpdatesTestUpdater(t, server)
+	logger := &testLogger{}
+	bot := &Bot{client: updater.client, updater: updater, logger: logger}
+
+	handled := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go bot.Dispatch(ctx, HandlerFunc(func(ctx context.Context, event Event) error {
+		if event.EventID != 1 {
+			return nil
+		}
+
+		assert.Same(t, bot, BotFromContext(ctx))
+		ctxEvent, ok := EventFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, event.EventID, ctxEvent.EventID)
+
+		LoggerFromContext(ctx).Log(LogLevelInfo, "handled", nil)
+		close(handled)
+		return nil
+	}), DispatcherConfig{Workers: 1})
+
+	<-handled
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	require.NotEmpty(t, logger.records)
+	assert.Equal(t, LogFields{"event_id": 1, "chat_id": "chat"}, logger.records[len(logger.records)-1].fields)
+
+	_, ok := EventFromContext(context.Background())
+	assert.False(t, ok)
+	assert.Nil(t, BotFromContext(context.Background()))
+}
+
+func TestBot_EventContext_CallbackQuery(t *testing.T) {
+	logger := &testLogger{}
+	bot := &Bot{logger: logger}
+
+	event := Event{EventID: 8, Type: CALLBACK_QUERY, Payload: EventPayload{
+		CallbackMsg: BaseEventPayload{Chat: Chat{ID: "chat"}},
+	}}
+	LoggerFromContext(bot.eventContext(context.Background(), event)).Log(LogLevelInfo, "handled", nil)
+
+	require.Len(t, logger.records, 1)
+	assert.Equal(t, LogFields{"event_id": 8, "chat_id": "chat"}, logger.records[0].fields)
+}
@@ -0,0 +1,145 @@
+package botgolang
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mail-ru-im/bot-golang/internal/atomicfile"
+)
+
+// OffsetStore persists the id of the last handled event, so the updater continues from it after restart
+type OffsetStore interface {
+	// Load returns the stored event id or 0 if nothing is stored
+	Load() (int, error)
+
+	// Save stores the event id
+	Save(eventID int) error
+}
+
+// MemoryOffsetStore keeps the event id in memory
+type MemoryOffsetStore struct {
+	mu      sync.Mutex
+	eventID int
+}
+
+// NewMemoryOffsetStore returns new in-memory offset store
+func NewMemoryOffsetStore() *MemoryOffsetStore {
+	return &MemoryOffsetStore{}
+}
+
+func (s *MemoryOffsetStore) Load() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.eventID, nil
+}
+
+func (s *MemoryOffsetStore) Save(eventID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eventID = eventID
+	return nil
+}
+
+// FileOffsetStore keeps the event id in a file which is replaced atomically on every save
+type FileOffsetStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileOffsetStore returns new offset store writing to the file
+func NewFileOffsetStore(path string) *FileOffsetStore {
+	return &FileOffsetStore{path: path}
+}
+
+func (s *FileOffsetStore) Load() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("cannot read offset file: %w", err)
+	}
+
+	eventID, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse offset file: %w", err)
+	}
+	return eventID, nil
+}
+
+func (s *FileOffsetStore) Save(eventID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := atomicfile.Write(s.path, []byte(strconv.Itoa(eventID)+"\n")); err != nil {
+		return fmt.Errorf("cannot write offset file: %w", err)
+	}
+	return nil
+}
+
+// offsetTracker commits the id of the last event such that it and all events before it are acknowledged
+type offsetTracker struct {
+	mu        sync.Mutex
+	store     OffsetStore
+	committed int
+	pending   []int
+	acked     map[int]bool
+}
+
+func newOffsetTracker(store OffsetStore, committed int) *offsetTracker {
+	return &offsetTracker{
+		store:     store,
+		committed: committed,
+		acked:     make(map[int]bool),
+	}
+}
+
+// track registers the event delivered to handlers, events must be tracked in order of their ids
+func (t *offsetTracker) track(eventID int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, eventID)
+}
+
+// untrack removes the last tracked event which was not delivered to handlers
+func (t *offsetTracker) untrack(eventID int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if n := len(t.pending); n > 0 && t.pending[n-1] == eventID {
+		t.pending = t.pending[:n-1]
+	}
+}
+
+// ack marks the event as handled and saves the new offset if it is moved
+func (t *offsetTracker) ack(eventID int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if eventID <= t.committed {
+		return nil
+	}
+	t.acked[eventID] = true
+
+	committed := t.committed
+	for len(t.pending) > 0 && t.acked[t.pending[0]] {
+		committed = t.pending[0]
+		delete(t.acked, committed)
+		t.pending = t.pending[1:]
+	}
+	if committed == t.committed {
+		return nil
+	}
+
+	if err := t.store.Save(committed); err != nil {
+		return fmt.Errorf("cannot save offset %d: %w", committed, err)
+	}
+	t.committed = committed
+	return nil
+}
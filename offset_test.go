@@ -0,0 +1,103 @@
+package botgolang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffsetTracker_Ack(t *testing.T) {
+	store := NewMemoryOffsetStore()
+	tracker := newOffsetTracker(store, 10)
+	for _, id := range []int{11, 12, 13} {
+		tracker.track(id)
+	}
+
+	require.NoError(t, tracker.ack(12))
+	offset, _ := store.Load()
+	assert.Equal(t, 0, offset, "event 11 is not acknowledged yet")
+
+	require.NoError(t, tracker.ack(11))
+	offset, _ = store.Load()
+	assert.Equal(t, 12, offset)
+
+	require.NoError(t, tracker.ack(11))
+	require.NoError(t, tracker.ack(13))
+	offset, _ = store.Load()
+	assert.Equal(t, 13, offset)
+}
+
+func TestFileOffsetStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offset")
+	store := NewFileOffsetStore(path)
+
+	offset, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 0, offset)
+
+	require.NoError(t, store.Save(42))
+	offset, err = NewFileOffsetStore(path).Load()
+	require.NoError(t, err)
+	assert.Equal(t, 42, offset)
+
+	require.NoError(t, os.WriteFile(path, []byte("broken"), 0o600))
+	_, err = store.Load()
+	require.Error(t, err)
+}
+
+func TestUpdater_RunUpdatesCheck_OffsetStore(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventID := r.URL.Query().Get("lastEventId")
+		mu.Lock()
+		requested = append(requested, lastEventID)
+		mu.Unlock()
+
+		if lastEventID != "5" {
+			_, _ = w.Write([]byte(`{"ok":true,"events":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"events":[
+			{"eventId":6,"type":"newMessage","payload":{"msgId":"6"}},
+			{"eventId":7,"type":"newMessage","payload":{"msgId":"7"}}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	store := NewMemoryOffsetStore()
+	require.NoError(t, store.Save(5))
+
+	client := NewCustomClientWithLogger(http.DefaultClient, server.URL, "test_token", nil)
+	updater, err := NewUpdaterFromStore(client, 1, nil, store)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan Event)
+	go updater.RunUpdatesCheck(ctx, ch)
+
+	first := <-ch
+	second := <-ch
+	cancel()
+
+	require.NoError(t, second.Ack())
+	offset, _ := store.Load()
+	assert.Equal(t, 5, offset)
+
+	require.NoError(t, first.Ack())
+	offset, _ = store.Load()
+	assert.Equal(t, 7, offset)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "5", requested[0], "updater continues from the stored offset without the initial request")
+}
@@ -36,3 +36,191 @@ func (o BotHTTPClient) Type() string {
 func (o BotHTTPClient) Value() interface{} {
 	return http.Client(o)
 }
+
+// BotRetryPolicy sets the policy of retrying failed API requests
+type BotRetryPolicy RetryPolicy
+
+func (o BotRetryPolicy) Type() string {
+	return "retry_policy"
+}
+
+func (o BotRetryPolicy) Value() interface{} {
+	return RetryPolicy(o)
+}
+
+// BotMaxUploadSize sets the maximum size of uploaded files in bytes
+type BotMaxUploadSize int64
+
+func (o BotMaxUploadSize) Type() string {
+	return "max_upload_size"
+}
+
+func (o BotMaxUploadSize) Value() interface{} {
+	return int64(o)
+}
+
+// BotUploadProgress sets the callback which receives the progress of file uploads
+type BotUploadProgress UploadProgressFunc
+
+func (o BotUploadProgress) Type() string {
+	return "upload_progress"
+}
+
+func (o BotUploadProgress) Value() interface{} {
+	return UploadProgressFunc(o)
+}
+
+// BotMaxDownloadSize sets the maximum size of downloaded files in bytes
+type BotMaxDownloadSize int64
+
+func (o BotMaxDownloadSize) Type() string {
+	return "max_download_size"
+}
+
+func (o BotMaxDownloadSize) Value() interface{} {
+	return int64(o)
+}
+
+type botRateLimiter struct {
+	limiter RateLimiter
+}
+
+// BotRateLimiter sets the limiter which paces requests to API, see NewRateLimiter
+func BotRateLimiter(limiter RateLimiter) BotOption {
+	return botRateLimiter{limiter: limiter}
+}
+
+func (o botRateLimiter) Type() string {
+	return "rate_limiter"
+}
+
+func (o botRateLimiter) Value() interface{} {
+	return o.limiter
+}
+
+type botInterceptors []Interceptor
+
+// BotInterceptors adds interceptors around every API call, the first one is the outermost
+func BotInterceptors(interceptors ...Interceptor) BotOption {
+	return botInterceptors(interceptors)
+}
+
+func (o botInterceptors) Type() string {
+	return "interceptors"
+}
+
+func (o botInterceptors) Value() interface{} {
+	return []Interceptor(o)
+}
+
+type botMetrics struct {
+	metrics Metrics
+}
+
+// BotMetrics sets the receiver of API calls and updater measurements
+func BotMetrics(metrics Metrics) BotOption {
+	return botMetrics{metrics: metrics}
+}
+
+func (o botMetrics) Type() string {
+	return "metrics"
+}
+
+func (o botMetrics) Value() interface{} {
+	return o.metrics
+}
+
+type botTracer struct {
+	tracer Tracer
+}
+
+// BotTracer sets the tracer which starts spans of API calls and received events
+func BotTracer(tracer Tracer) BotOption {
+	return botTracer{tracer: tracer}
+}
+
+func (o botTracer) Type() string {
+	return "tracer"
+}
+
+func (o botTracer) Value() interface{} {
+	return o.tracer
+}
+
+type botLogger struct {
+	logger Logger
+}
+
+// BotLogger sets the logger of the bot instead of the default logrus logger, BotDebug has no effect with it.
+// Use NewSlogLogger or NewLogrusLogger to adapt your logger.
+func BotLogger(logger Logger) BotOption {
+	return botLogger{logger: logger}
+}
+
+func (o botLogger) Type() string {
+	return "logger"
+}
+
+func (o botLogger) Value() interface{} {
+	return o.logger
+}
+
+type botPostEndpoints struct {
+	paths []string
+}
+
+// BotPostEndpoints sends params of the API methods in form-encoded POST body instead of URL query,
+// e.g. BotPostEndpoints("/messages/sendText", "/messages/editText")
+func BotPostEndpoints(paths ...string) BotOption {
+	return botPostEndpoints{paths: paths}
+}
+
+func (o botPostEndpoints) Type() string {
+	return "post_endpoints"
+}
+
+func (o botPostEndpoints) Value() interface{} {
+	return o.paths
+}
+
+// BotMaxURLLength sets the length of request URL after which params are sent in POST body,
+// zero means DefaultMaxURLLength and negative value disables the fallback
+type BotMaxURLLength int
+
+func (o BotMaxURLLength) Type() string {
+	return "max_url_length"
+}
+
+func (o BotMaxURLLength) Value() interface{} {
+	return int(o)
+}
+
+// BotMaxTextLength sets the length of text in UTF-16 code units after which SendSplit splits messages,
+// zero means DefaultMaxTextLength
+type BotMaxTextLength int
+
+func (o BotMaxTextLength) Type() string {
+	return "max_text_length"
+}
+
+func (o BotMaxTextLength) Value() interface{} {
+	return int(o)
+}
+
+type botOffsetStore struct {
+	store OffsetStore
+}
+
+// BotOffsetStore makes the updater continue from the stored offset after restart.
+// Events must be acknowledged with Event.Ack to move the offset.
+func BotOffsetStore(store OffsetStore) BotOption {
+	return botOffsetStore{store: store}
+}
+
+func (o botOffsetStore) Type() string {
+	return "offset_store"
+}
+
+func (o botOffsetStore) Value() interface{} {
+	return o.store
+}
@@ -0,0 +1,279 @@
+// Package paginator shows long lists as inline keyboards split into pages.
+// Navigation buttons edit the message in place, buttons of items call the select handler.
+package paginator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+const (
+	defaultPageSize    = 10
+	defaultPageButtons = 5
+
+	pageAction = "page"
+	itemAction = "item"
+	noopAction = "noop"
+)
+
+// SelectHandler handles the press of the item button
+type SelectHandler func(ctx context.Context, selection *Selection) error
+
+// RenderFunc sets the text of the message with the list for the page, e.g. with a parse mode or format
+type RenderFunc func(ctx context.Context, message *botgolang.Message, page int) error
+
+// Selection is the item chosen by the user
+type Selection struct {
+	// ID of the item
+	ID string
+
+	// Page where the item was chosen
+	Page int
+
+	// Event of the callback query
+	Event botgolang.Event
+
+	// Response to the query, it is sent after the handler if the handler does not send it
+	Response *botgolang.ButtonResponse
+
+	// Message with the list
+	Message *botgolang.Message
+}
+
+// Config configures the paginator
+type Config struct {
+	// Number of items on the page, defaults to 10
+	PageSize int
+
+	// Number of item buttons in the row, defaults to 1
+	Columns int
+
+	// Number of buttons with page numbers, defaults to 5
+	PageButtons int
+
+	// OnSelect is called when the button of an item is pressed
+	OnSelect SelectHandler
+
+	// Render is called by Send and before the message is edited to show another page.
+	// Callback queries carry the text of the message without formatting, so a formatted message
+	// loses its markup on page turns unless Render sets the text again.
+	Render RenderFunc
+}
+
+// Paginator renders pages of items and handles its callback queries, it implements botgolang.Handler.
+// Callback data of the buttons starts with "pager/<name>/", so items should have short IDs.
+type Paginator struct {
+	name   string
+	source Source
+	config Config
+}
+
+// New returns new paginator, the name distinguishes its buttons from buttons of other paginators
+func New(name string, source Source, config Config) *Paginator {
+	if config.PageSize <= 0 {
+		config.PageSize = defaultPageSize
+	}
+	if config.Columns <= 0 {
+		config.Columns = 1
+	}
+	if config.PageButtons <= 0 {
+		config.PageButtons = defaultPageButtons
+	}
+
+	return &Paginator{name: name, source: source, config: config}
+}
+
+// Pattern returns the pattern of callback data for router.CallbackRouter
+func (p *Paginator) Pattern() string {
+	return "pager/" + p.name + "/*action"
+}
+
+// Keyboard renders the page, pages are numbered from zero.
+// The page is limited by the number of pages, the rendered page is returned.
+func (p *Paginator) Keyboard(ctx context.Context, page int) (botgolang.Keyboard, int, error) {
+	keyboard := botgolang.NewKeyboard()
+
+	if page < 0 {
+		page = 0
+	}
+	items, total, err := p.source.Items(ctx, page*p.config.PageSize, p.config.PageSize)
+	if err != nil {
+		return keyboard, page, fmt.Errorf("cannot get items: %w", err)
+	}
+
+	pages := (total + p.config.PageSize - 1) / p.config.PageSize
+	if pages > 0 && page >= pages {
+		// the list became shorter, show the last page
+		page = pages - 1
+		items, total, err = p.source.Items(ctx, page*p.config.PageSize, p.config.PageSize)
+		if err != nil {
+			return keyboard, page, fmt.Errorf("cannot get items: %w", err)
+		}
+		pages = (total + p.config.PageSize - 1) / p.config.PageSize
+	}
+
+	var row []botgolang.Button
+	for _, item := range items {
+		row = append(row, botgolang.NewCallbackButton(item.Text, p.data(itemAction, page, item.ID)))
+		if len(row) == p.config.Columns {
+			keyboard.AddRow(row...)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard.AddRow(row...)
+	}
+
+	if pages > 1 {
+		keyboard.AddRow(p.navigation(page, pages)...)
+	}
+
+	return keyboard, page, nil
+}
+
+// navigation returns prev/next buttons and buttons of pages around the current one
+func (p *Paginator) navigation(page, pages int) []botgolang.Button {
+	first := page - p.config.PageButtons/2
+	if first > pages-p.config.PageButtons {
+		first = pages - p.config.PageButtons
+	}
+	if first < 0 {
+		first = 0
+	}
+	last := first + p.config.PageButtons
+	if last > pages {
+		last = pages
+	}
+
+	var buttons []botgolang.Button
+	if page > 0 {
+		buttons = append(buttons, botgolang.NewCallbackButton("«", p.data(pageAction, page-1, "")))
+	}
+	for i := first; i < last; i++ {
+		if i == page {
+			buttons = append(buttons, botgolang.NewCallbackButton("· "+strconv.Itoa(i+1)+" ·", p.data(noopAction, i, "")))
+			continue
+		}
+		buttons = append(buttons, botgolang.NewCallbackButton(strconv.Itoa(i+1), p.data(pageAction, i, "")))
+	}
+	if page < pages-1 {
+		buttons = append(buttons, botgolang.NewCallbackButton("»", p.data(pageAction, page+1, "")))
+	}
+
+	return buttons
+}
+
+// Send attaches the page to the message and sends it
+func (p *Paginator) Send(ctx context.Context, message *botgolang.Message, page int) error {
+	keyboard, page, err := p.Keyboard(ctx, page)
+	if err != nil {
+		return err
+	}
+	if err := p.render(ctx, message, page); err != nil {
+		return err
+	}
+
+	message.AttachInlineKeyboard(keyboard)
+	return message.SendWithContext(ctx)
+}
+
+// Handle handles callback queries of the paginator, other events are ignored.
+// Navigation buttons edit the message with the list, every query of the paginator is answered.
+func (p *Paginator) Handle(ctx context.Context, event botgolang.Event) (err error) {
+	if event.Type != botgolang.CALLBACK_QUERY {
+		return nil
+	}
+	action, page, id, ok := p.parse(event.Payload.CallbackData)
+	if !ok {
+		return nil
+	}
+
+	response := event.Payload.CallbackQuery()
+	defer func() {
+		if response.Answered() {
+			return
+		}
+		if answerErr := response.SendWithContext(ctx); answerErr != nil && err == nil {
+			err = fmt.Errorf("cannot answer callback query: %w", answerErr)
+		}
+	}()
+
+	switch action {
+	case pageAction:
+		return p.edit(ctx, event.Payload.CallbackMessage(), page)
+	case itemAction:
+		if p.config.OnSelect == nil {
+			return nil
+		}
+		return p.config.OnSelect(ctx, &Selection{
+			ID:       id,
+			Page:     page,
+			Event:    event,
+			Response: response,
+			Message:  event.Payload.CallbackMessage(),
+		})
+	}
+
+	return nil
+}
+
+// edit replaces the keyboard of the message with the page
+func (p *Paginator) edit(ctx context.Context, message *botgolang.Message, page int) error {
+	keyboard, page, err := p.Keyboard(ctx, page)
+	if err != nil {
+		return err
+	}
+	if err := p.render(ctx, message, page); err != nil {
+		return err
+	}
+
+	message.AttachInlineKeyboard(keyboard)
+	if err := message.EditWithContext(ctx); err != nil {
+		return fmt.Errorf("cannot show page %d: %w", page+1, err)
+	}
+	return nil
+}
+
+func (p *Paginator) render(ctx context.Context, message *botgolang.Message, page int) error {
+	if p.config.Render == nil {
+		return nil
+	}
+	if err := p.config.Render(ctx, message, page); err != nil {
+		return fmt.Errorf("cannot render page %d: %w", page+1, err)
+	}
+	return nil
+}
+
+func (p *Paginator) data(action string, page int, id string) string {
+	data := "pager/" + p.name + "/" + action + "/" + strconv.Itoa(page)
+	if id != "" {
+		data += "/" + id
+	}
+	return data
+}
+
+// parse returns the action of the callback data, ok is false for data of other buttons
+func (p *Paginator) parse(data string) (action string, page int, id string, ok bool) {
+	rest, ok := strings.CutPrefix(data, "pager/"+p.name+"/")
+	if !ok {
+		return "", 0, "", false
+	}
+
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) < 2 {
+		return "", 0, "", false
+	}
+	page, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, "", false
+	}
+	if len(parts) == 3 {
+		id = parts[2]
+	}
+
+	return parts[0], page, id, true
+}
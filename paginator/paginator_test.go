@@ -0,0 +1,124 @@
+package paginator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+	"github.com/mail-ru-im/bot-golang/internal/bottest"
+)
+
+const callbackEvent = `{"eventId":1,"type":"callbackQuery","payload":{"queryId":"query","callbackData":%q,"from":{"userId":"user"},"message":{"msgId":"list","text":"Tickets","chat":{"chatId":"chat"}}}}`
+
+func testItems(count int) SliceSource {
+	items := make(SliceSource, count)
+	for i := range items {
+		items[i] = Item{ID: strconv.Itoa(i), Text: "item " + strconv.Itoa(i)}
+	}
+	return items
+}
+
+func texts(row []botgolang.Button) []string {
+	var texts []string
+	for _, button := range row {
+		texts = append(texts, button.Text)
+	}
+	return texts
+}
+
+func TestPaginator_Keyboard(t *testing.T) {
+	p := New("tickets", testItems(95), Config{PageSize: 10, Columns: 3})
+
+	keyboard, page, err := p.Keyboard(context.Background(), 4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, page)
+
+	rows := keyboard.GetKeyboard()
+	require.Len(t, rows, 5)
+	assert.Equal(t, []string{"item 40", "item 41", "item 42"}, texts(rows[0]))
+	assert.Equal(t, []string{"item 49"}, texts(rows[3]))
+	assert.Equal(t, "pager/tickets/item/4/41", rows[0][1].CallbackData)
+	assert.Equal(t, []string{"«", "3", "4", "· 5 ·", "6", "7", "»"}, texts(rows[4]))
+	assert.Equal(t, "pager/tickets/page/3", rows[4][0].CallbackData)
+
+	keyboard, page, err = p.Keyboard(context.Background(), 20)
+	require.NoError(t, err)
+	assert.Equal(t, 9, page)
+	rows = keyboard.GetKeyboard()
+	assert.Equal(t, []string{"item 90", "item 91", "item 92"}, texts(rows[0]))
+	assert.Equal(t, []string{"«", "6", "7", "8", "9", "· 10 ·"}, texts(rows[len(rows)-1]))
+
+	keyboard, _, err = New("short", testItems(2), Config{}).Keyboard(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, keyboard.RowsCount())
+}
+
+func TestPaginator_Navigation(t *testing.T) {
+	api := bottest.NewAPI(t)
+	p := New("tickets", testItems(30), Config{})
+
+	event := api.Receive(fmt.Sprintf(callbackEvent, "pager/tickets/page/2"))
+	require.NoError(t, p.Handle(context.Background(), event))
+
+	sent := api.Sent()
+	require.Len(t, sent, 2)
+	assert.Equal(t, "/messages/editText", sent[0].Path)
+	assert.Equal(t, "list", sent[0].Params.Get("msgId"))
+	assert.Equal(t, "Tickets", sent[0].Params.Get("text"))
+
+	var rows [][]botgolang.Button
+	require.NoError(t, json.Unmarshal([]byte(sent[0].Params.Get("inlineKeyboardMarkup")), &rows))
+	assert.Equal(t, "item 20", rows[0][0].Text)
+	assert.Equal(t, "/messages/answerCallbackQuery", sent[1].Path)
+}
+
+func TestPaginator_Render(t *testing.T) {
+	api := bottest.NewAPI(t)
+	p := New("tickets", testItems(30), Config{
+		Render: func(ctx context.Context, message *botgolang.Message, page int) error {
+			message.Text = fmt.Sprintf("<b>Tickets</b>, page %d", page+1)
+			message.ParseMode = botgolang.ParseModeHTML
+			return nil
+		},
+	})
+
+	require.NoError(t, p.Handle(context.Background(), api.Receive(fmt.Sprintf(callbackEvent, "pager/tickets/page/1"))))
+
+	sent := api.Sent()
+	require.Len(t, sent, 2)
+	assert.Equal(t, "/messages/editText", sent[0].Path)
+	assert.Equal(t, "<b>Tickets</b>, page 2", sent[0].Params.Get("text"))
+	assert.Equal(t, string(botgolang.ParseModeHTML), sent[0].Params.Get("parseMode"))
+}
+
+func TestPaginator_Select(t *testing.T) {
+	api := bottest.NewAPI(t)
+
+	var selected *Selection
+	p := New("tickets", testItems(30), Config{
+		OnSelect: func(ctx context.Context, selection *Selection) error {
+			selected = selection
+			selection.Response.Text = "chosen"
+			return nil
+		},
+	})
+
+	require.NoError(t, p.Handle(context.Background(), api.Receive(fmt.Sprintf(callbackEvent, "pager/tickets/item/1/a/b"))))
+	require.NotNil(t, selected)
+	assert.Equal(t, "a/b", selected.ID)
+	assert.Equal(t, 1, selected.Page)
+	assert.Equal(t, "list", selected.Message.ID)
+
+	require.NoError(t, p.Handle(context.Background(), api.Receive(fmt.Sprintf(callbackEvent, "pager/other/page/1"))))
+
+	sent := api.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "/messages/answerCallbackQuery", sent[0].Path)
+	assert.Equal(t, "chosen", sent[0].Params.Get("text"))
+}
@@ -0,0 +1,38 @@
+package paginator
+
+import "context"
+
+// Item is an entry of the list, ID is passed to the select handler when the button of the item is pressed
+type Item struct {
+	ID   string
+	Text string
+}
+
+// Source provides items of the list
+type Source interface {
+	// Items returns up to limit items starting from offset and the total number of items
+	Items(ctx context.Context, offset, limit int) (items []Item, total int, err error)
+}
+
+// SourceFunc is an adapter to use ordinary functions as sources
+type SourceFunc func(ctx context.Context, offset, limit int) ([]Item, int, error)
+
+// Items calls f(ctx, offset, limit)
+func (f SourceFunc) Items(ctx context.Context, offset, limit int) ([]Item, int, error) {
+	return f(ctx, offset, limit)
+}
+
+// SliceSource is a source of items kept in memory
+type SliceSource []Item
+
+// Items returns the items of the slice
+func (s SliceSource) Items(_ context.Context, offset, limit int) ([]Item, int, error) {
+	if offset > len(s) {
+		offset = len(s)
+	}
+	end := offset + limit
+	if end > len(s) {
+		end = len(s)
+	}
+	return s[offset:end], len(s), nil
+}
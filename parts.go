@@ -0,0 +1,201 @@
+package botgolang
+
+import (
+	"encoding/json"
+
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// FilePart is a file attached to the message
+type FilePart struct {
+	// Id of the file, use it to get info or download the file
+	FileID string
+
+	// Type of the file: image, video, audio, etc.
+	Type string
+
+	// Caption of the file
+	Caption string
+}
+
+// VoicePart is a voice message
+type VoicePart struct {
+	FileID string
+}
+
+// StickerPart is a sticker
+type StickerPart struct {
+	FileID string
+}
+
+// Mentions returns users mentioned in the message
+func (ep *EventPayload) Mentions() []Contact {
+	var mentions []Contact
+	for _, part := range ep.Parts {
+		if part.Type == MENTION {
+			mentions = append(mentions, Contact{
+				User:      User{ID: part.Payload.UserID},
+				FirstName: part.Payload.FirstName,
+				LastName:  part.Payload.LastName,
+			})
+		}
+	}
+	return mentions
+}
+
+// Files returns files attached to the message
+func (ep *EventPayload) Files() []FilePart {
+	var files []FilePart
+	for _, part := range ep.Parts {
+		if part.Type == FILE {
+			files = append(files, FilePart{
+				FileID:  part.Payload.FileID,
+				Type:    part.Payload.Type,
+				Caption: part.Payload.Caption,
+			})
+		}
+	}
+	return files
+}
+
+// Voice returns the voice message or nil if the message is not a voice one
+func (ep *EventPayload) Voice() *VoicePart {
+	if part := ep.part(VOICE); part != nil {
+		return &VoicePart{FileID: part.Payload.FileID}
+	}
+	return nil
+}
+
+// Sticker returns the sticker or nil if the message has no sticker
+func (ep *EventPayload) Sticker() *StickerPart {
+	if part := ep.part(STICKER); part != nil {
+		return &StickerPart{FileID: part.Payload.FileID}
+	}
+	return nil
+}
+
+// ReplyTo returns the message replied by this one or nil if the message is not a reply.
+// The message is bound to the client, so it can be replied, forwarded, etc.
+func (ep *EventPayload) ReplyTo() *Message {
+	if part := ep.part(REPLY); part != nil {
+		return ep.partMessage(part.Payload.PartMessage)
+	}
+	return nil
+}
+
+// Forwards returns forwarded messages bound to the client
+func (ep *EventPayload) Forwards() []*Message {
+	var messages []*Message
+	for _, part := range ep.Parts {
+		if part.Type == FORWARD {
+			messages = append(messages, ep.partMessage(part.Payload.PartMessage))
+		}
+	}
+	return messages
+}
+
+// InlineKeyboard returns the inline keyboard of the message or nil if the message has no keyboard
+func (ep *EventPayload) InlineKeyboard() *Keyboard {
+	if part := ep.part(INLINE_KEYBOARD); part != nil {
+		return &Keyboard{Rows: part.Payload.InlineKeyboard}
+	}
+	return nil
+}
+
+func (ep *EventPayload) part(partType PartType) *Part {
+	for i := range ep.Parts {
+		if ep.Parts[i].Type == partType {
+			return &ep.Parts[i]
+		}
+	}
+	return nil
+}
+
+// partMessage returns the message of the part, messages without chat belong to the chat of the event
+func (ep *EventPayload) partMessage(msg PartMessage) *Message {
+	chat := msg.Chat
+	if chat.ID == "" {
+		chat = ep.Chat
+	}
+	chat.client = ep.client
+
+	return &Message{
+		client:    ep.client,
+		ID:        msg.MsgID,
+		Text:      msg.Text,
+		Chat:      chat,
+		Timestamp: msg.Timestamp,
+	}
+}
+
+// UnmarshalEasyJSON decodes the part, the payload is decoded when its type is known
+func (p *Part) UnmarshalEasyJSON(in *jlexer.Lexer) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+
+	var payload []byte
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			p.Type = PartType(in.String())
+		case "payload":
+			payload = append([]byte(nil), in.Raw()...)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+
+	if payload != nil && in.Ok() {
+		if p.Type == INLINE_KEYBOARD {
+			in.AddError(json.Unmarshal(payload, &p.Payload.InlineKeyboard))
+		} else {
+			in.AddError(easyjson.Unmarshal(payload, &p.Payload))
+		}
+	}
+
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+
+// MarshalEasyJSON encodes the part, the payload of inlineKeyboardMarkup part is encoded as an array of rows
+func (p Part) MarshalEasyJSON(out *jwriter.Writer) {
+	out.RawString(`{"type":`)
+	out.String(string(p.Type))
+	out.RawString(`,"payload":`)
+	if p.Type == INLINE_KEYBOARD {
+		data, err := json.Marshal(p.Payload.InlineKeyboard)
+		out.Raw(data, err)
+	} else {
+		p.Payload.MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (p *Part) UnmarshalJSON(data []byte) error {
+	return easyjson.Unmarshal(data, p)
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (p Part) MarshalJSON() ([]byte, error) {
+	return easyjson.Marshal(p)
+}
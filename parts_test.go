@@ -0,0 +1,79 @@
+package botgolang
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const partsEvent = `{
+	"eventId": 1,
+	"type": "newMessage",
+	"payload": {
+		"msgId": "100",
+		"chat": {"chatId": "chat", "type": "group"},
+		"from": {"userId": "author"},
+		"text": "look",
+		"parts": [
+			{"type": "mention", "payload": {"userId": "user1", "firstName": "First", "lastName": "Last"}},
+			{"type": "mention", "payload": {"userId": "user2"}},
+			{"payload": {"fileId": "file1", "type": "image", "caption": "photo"}, "type": "file"},
+			{"type": "voice", "payload": {"fileId": "Ivoice"}},
+			{"type": "sticker", "payload": {"fileId": "sticker"}},
+			{"type": "reply", "payload": {"message": {"msgId": "99", "text": "question", "from": {"userId": "user1"}}}},
+			{"type": "forward", "payload": {"message": {"msgId": "5", "text": "forwarded", "chat": {"chatId": "other"}}}},
+			{"type": "inlineKeyboardMarkup", "payload": [[{"text": "ok", "callbackData": "ok"}], [{"text": "site", "url": "https://example.com"}]]}
+		]
+	}
+}`
+
+func TestEventPayload_Parts(t *testing.T) {
+	client := NewApiMockClient(t)
+
+	var event Event
+	require.NoError(t, json.Unmarshal([]byte(partsEvent), &event))
+	event.Payload.client = &client
+	payload := &event.Payload
+
+	assert.Equal(t, []Contact{
+		{User: User{ID: "user1"}, FirstName: "First", LastName: "Last"},
+		{User: User{ID: "user2"}},
+	}, payload.Mentions())
+	assert.Equal(t, []FilePart{{FileID: "file1", Type: "image", Caption: "photo"}}, payload.Files())
+	assert.Equal(t, &VoicePart{FileID: "Ivoice"}, payload.Voice())
+	assert.Equal(t, &StickerPart{FileID: "sticker"}, payload.Sticker())
+
+	reply := payload.ReplyTo()
+	require.NotNil(t, reply)
+	assert.Equal(t, "99", reply.ID)
+	assert.Equal(t, "question", reply.Text)
+	assert.Equal(t, "chat", reply.Chat.ID)
+	require.NoError(t, reply.Reply("answer"))
+
+	forwards := payload.Forwards()
+	require.Len(t, forwards, 1)
+	assert.Equal(t, "other", forwards[0].Chat.ID)
+
+	keyboard := payload.InlineKeyboard()
+	require.NotNil(t, keyboard)
+	require.Equal(t, 2, keyboard.RowsCount())
+	assert.Equal(t, "ok", keyboard.Rows[0][0].CallbackData)
+	assert.Equal(t, "https://example.com", keyboard.Rows[1][0].URL)
+
+	data, err := json.Marshal(payload.Parts[7])
+	require.NoError(t, err)
+	var part Part
+	require.NoError(t, json.Unmarshal(data, &part))
+	assert.Equal(t, payload.Parts[7], part)
+}
+
+func TestEventPayload_Parts_Empty(t *testing.T) {
+	payload := &EventPayload{}
+
+	assert.Nil(t, payload.Mentions())
+	assert.Nil(t, payload.Voice())
+	assert.Nil(t, payload.ReplyTo())
+	assert.Nil(t, payload.InlineKeyboard())
+}
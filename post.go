@@ -0,0 +1,29 @@
+package botgolang
+
+import (
+	"net/url"
+)
+
+// DefaultMaxURLLength is the length of request URL after which params are sent in POST body
+const DefaultMaxURLLength = 2048
+
+// usePost reports whether params of the request are sent in form-encoded POST body instead of URL query
+func (c *Client) usePost(path string, apiURL *url.URL) bool {
+	if c.postPaths[path] {
+		return true
+	}
+
+	maxLength := c.maxURLLength
+	if maxLength == 0 {
+		maxLength = DefaultMaxURLLength
+	}
+	return maxLength > 0 && len(apiURL.String()) > maxLength
+}
+
+func cloneValues(values url.Values) url.Values {
+	clone := make(url.Values, len(values))
+	for key, value := range values {
+		clone[key] = append([]string(nil), value...)
+	}
+	return clone
+}
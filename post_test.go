@@ -0,0 +1,94 @@
+package botgolang
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type postTestRequest struct {
+	method      string
+	query       url.Values
+	contentType string
+	body        string
+}
+
+func newPostTestServer(t *testing.T, requests *[]postTestRequest) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		*requests = append(*requests, postTestRequest{
+			method:      r.Method,
+			query:       r.URL.Query(),
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+		})
+		_, _ = w.Write([]byte(`{"ok":true,"msgId":"1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_Do_PostEndpoints(t *testing.T) {
+	var requests []postTestRequest
+	server := newPostTestServer(t, &requests)
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	client.postPaths = map[string]bool{"/messages/sendText": true}
+
+	require.NoError(t, client.SendTextMessage(&Message{Chat: Chat{ID: "chat"}, Text: "hello"}))
+	require.NoError(t, client.SendChatActions("chat", TypingAction))
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, http.MethodPost, requests[0].method)
+	assert.Equal(t, url.Values{"token": {"test_token"}}, requests[0].query)
+	assert.Equal(t, "application/x-www-form-urlencoded", requests[0].contentType)
+	assert.Equal(t, "chatId=chat&request-id=&text=hello", requests[0].body)
+
+	assert.Equal(t, http.MethodGet, requests[1].method)
+	assert.Equal(t, "chat", requests[1].query.Get("chatId"))
+	assert.Empty(t, requests[1].body)
+}
+
+func TestClient_Do_PostLongURL(t *testing.T) {
+	var requests []postTestRequest
+	server := newPostTestServer(t, &requests)
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+
+	longText := strings.Repeat("text ", DefaultMaxURLLength/5)
+	require.NoError(t, client.SendTextMessage(&Message{Chat: Chat{ID: "chat"}, Text: "short"}))
+	require.NoError(t, client.SendTextMessage(&Message{Chat: Chat{ID: "chat"}, Text: longText}))
+
+	client.maxURLLength = -1
+	require.NoError(t, client.SendTextMessage(&Message{Chat: Chat{ID: "chat"}, Text: longText}))
+
+	require.Len(t, requests, 3)
+	assert.Equal(t, http.MethodGet, requests[0].method)
+
+	assert.Equal(t, http.MethodPost, requests[1].method)
+	assert.Equal(t, url.Values{"token": {"test_token"}}, requests[1].query)
+	form, err := url.ParseQuery(requests[1].body)
+	require.NoError(t, err)
+	assert.Equal(t, longText, form.Get("text"))
+
+	assert.Equal(t, http.MethodGet, requests[2].method)
+	assert.Equal(t, longText, requests[2].query.Get("text"))
+}
+
+func TestClient_Do_PostWithApiMock(t *testing.T) {
+	client := NewApiMockClient(t)
+	client.postPaths = map[string]bool{"/messages/sendText": true, "/messages/sendFile": true}
+
+	message := &Message{Chat: Chat{ID: "chat"}, Text: "text"}
+	require.NoError(t, client.SendTextMessage(message))
+	require.NoError(t, client.SendFileMessage(&Message{Chat: Chat{ID: "chat"}, FileID: "file"}))
+}
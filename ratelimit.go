@@ -0,0 +1,249 @@
+package botgolang
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// per-chat buckets which were not used for this time are removed
+	chatBucketIdleTimeout = 10 * time.Minute
+	chatBucketsSweepSize  = 1024
+)
+
+// RateLimiter paces requests to API.
+// Client calls Wait before every request attempt and Backoff when API asks to slow down.
+type RateLimiter interface {
+	// Wait blocks until the request is allowed or the context is done
+	Wait(ctx context.Context, path string, params url.Values) error
+
+	// Backoff suspends requests related to the given one for the delay,
+	// it is called when API responds with 429 Too Many Requests and Retry-After header
+	Backoff(path string, params url.Values, delay time.Duration)
+}
+
+// RateLimiterConfig configures the token bucket rate limiter.
+// Zero rate disables the corresponding bucket.
+type RateLimiterConfig struct {
+	// Requests per second for all requests of the bot
+	GlobalRate float64
+
+	// Maximum number of requests sent at once, defaults to 1
+	GlobalBurst int
+
+	// Requests per second for each chat, the chat is taken from chatId parameter
+	ChatRate float64
+
+	// Maximum number of requests sent at once to one chat, defaults to 1
+	ChatBurst int
+
+	// OnWait is called after a request had to wait for the limiter
+	OnWait func(path, chatID string, wait time.Duration)
+}
+
+// RateLimiterStats is a snapshot of the rate limiter state
+type RateLimiterStats struct {
+	// Number of requests waiting for the limiter right now
+	QueueDepth int64
+
+	// Number of requests which had to wait
+	Delayed int64
+
+	// Total time spent by requests in waiting
+	TotalWait time.Duration
+
+	// Number of chats with an active bucket
+	Chats int
+}
+
+// TokenBucketLimiter is a RateLimiter with a global token bucket and a bucket per chat
+type TokenBucketLimiter struct {
+	config RateLimiterConfig
+	now    func() time.Time
+
+	mu     sync.Mutex
+	global *tokenBucket
+	chats  map[string]*tokenBucket
+
+	queueDepth int64
+	delayed    int64
+	totalWait  int64
+}
+
+// NewRateLimiter returns new token bucket rate limiter
+func NewRateLimiter(config RateLimiterConfig) *TokenBucketLimiter {
+	if config.GlobalBurst < 1 {
+		config.GlobalBurst = 1
+	}
+	if config.ChatBurst < 1 {
+		config.ChatBurst = 1
+	}
+
+	limiter := &TokenBucketLimiter{
+		config: config,
+		now:    time.Now,
+		chats:  make(map[string]*tokenBucket),
+	}
+	limiter.global = newTokenBucket(config.GlobalRate, config.GlobalBurst, limiter.now())
+
+	return limiter
+}
+
+// Wait blocks until both the global and the chat bucket allow the request.
+// Long polling of events is never limited.
+func (l *TokenBucketLimiter) Wait(ctx context.Context, path string, params url.Values) error {
+	if path == "/events/get" {
+		return nil
+	}
+
+	chatID := params.Get("chatId")
+
+	l.mu.Lock()
+	now := l.now()
+	chat := l.chatBucket(chatID, now)
+	delay := l.global.reserve(now)
+	if chat != nil {
+		if chatDelay := chat.reserve(now); chatDelay > delay {
+			delay = chatDelay
+		}
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	atomic.AddInt64(&l.queueDepth, 1)
+	defer atomic.AddInt64(&l.queueDepth, -1)
+
+	if err := sleepContext(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.global.cancel()
+		if chat != nil {
+			chat.cancel()
+		}
+		l.mu.Unlock()
+		return err
+	}
+
+	atomic.AddInt64(&l.delayed, 1)
+	atomic.AddInt64(&l.totalWait, int64(delay))
+	if l.config.OnWait != nil {
+		l.config.OnWait(path, chatID, delay)
+	}
+
+	return nil
+}
+
+// Backoff suspends requests to the chat of the request or all requests if there is no chat
+func (l *TokenBucketLimiter) Backoff(path string, params url.Values, delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket := l.chatBucket(params.Get("chatId"), now)
+	if bucket == nil {
+		bucket = l.global
+	}
+	bucket.suspend(now.Add(delay))
+}
+
+// Stats returns the current state of the limiter
+func (l *TokenBucketLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	chats := len(l.chats)
+	l.mu.Unlock()
+
+	return RateLimiterStats{
+		QueueDepth: atomic.LoadInt64(&l.queueDepth),
+		Delayed:    atomic.LoadInt64(&l.delayed),
+		TotalWait:  time.Duration(atomic.LoadInt64(&l.totalWait)),
+		Chats:      chats,
+	}
+}
+
+// chatBucket returns the bucket of the chat, creating it if needed.
+// Must be called with the lock held.
+func (l *TokenBucketLimiter) chatBucket(chatID string, now time.Time) *tokenBucket {
+	if chatID == "" || l.config.ChatRate <= 0 {
+		return nil
+	}
+
+	if len(l.chats) >= chatBucketsSweepSize {
+		for id, bucket := range l.chats {
+			if now.Sub(bucket.updated) > chatBucketIdleTimeout {
+				delete(l.chats, id)
+			}
+		}
+	}
+
+	bucket, ok := l.chats[chatID]
+	if !ok {
+		bucket = newTokenBucket(l.config.ChatRate, l.config.ChatBurst, now)
+		l.chats[chatID] = bucket
+	}
+	return bucket
+}
+
+// tokenBucket allows rate requests per second with bursts up to burst requests.
+// Tokens can go negative, it means that the requests are queued.
+type tokenBucket struct {
+	rate      float64
+	burst     float64
+	tokens    float64
+	updated   time.Time
+	suspended time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:    rate,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		updated: now,
+	}
+}
+
+// reserve takes a token and returns the delay after which the request may be sent
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	var delay time.Duration
+
+	if b.rate > 0 {
+		if now.After(b.updated) {
+			b.tokens += now.Sub(b.updated).Seconds() * b.rate
+			if b.tokens > b.burst {
+				b.tokens = b.burst
+			}
+			b.updated = now
+		}
+
+		b.tokens--
+		if b.tokens < 0 {
+			delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+		}
+	}
+
+	if suspended := b.suspended.Sub(now); suspended > delay {
+		delay = suspended
+	}
+
+	return delay
+}
+
+// cancel returns the token of a reservation which was not used
+func (b *tokenBucket) cancel() {
+	if b.rate <= 0 {
+		return
+	}
+	b.tokens++
+}
+
+// suspend blocks the bucket until the given time
+func (b *tokenBucket) suspend(until time.Time) {
+	if until.After(b.suspended) {
+		b.suspended = until
+	}
+}
@@ -0,0 +1,108 @@
+package botgolang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(2, 2, now)
+
+	assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve(now))
+	assert.Equal(t, time.Second, bucket.reserve(now))
+
+	bucket.cancel()
+	assert.Equal(t, time.Second, bucket.reserve(now))
+
+	// the bucket is refilled up to the burst
+	now = now.Add(3 * time.Second)
+	assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve(now))
+
+	bucket.suspend(now.Add(5 * time.Second))
+	assert.Equal(t, 5*time.Second, bucket.reserve(now))
+}
+
+func TestTokenBucketLimiter_PerChatBuckets(t *testing.T) {
+	var waits int32
+	limiter := NewRateLimiter(RateLimiterConfig{
+		ChatRate: 20,
+		OnWait: func(path, chatID string, wait time.Duration) {
+			assert.Equal(t, "chat1", chatID)
+			atomic.AddInt32(&waits, 1)
+		},
+	})
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Wait(ctx, "/messages/sendText", url.Values{"chatId": {"chat1"}}))
+	require.NoError(t, limiter.Wait(ctx, "/messages/sendText", url.Values{"chatId": {"chat2"}}))
+	require.NoError(t, limiter.Wait(ctx, "/self/get", url.Values{}))
+	assert.EqualValues(t, 0, atomic.LoadInt32(&waits))
+
+	require.NoError(t, limiter.Wait(ctx, "/messages/sendText", url.Values{"chatId": {"chat1"}}))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&waits))
+
+	stats := limiter.Stats()
+	assert.EqualValues(t, 1, stats.Delayed)
+	assert.EqualValues(t, 0, stats.QueueDepth)
+	assert.Equal(t, 2, stats.Chats)
+	assert.Greater(t, stats.TotalWait, time.Duration(0))
+}
+
+func TestTokenBucketLimiter_GlobalBucket(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{GlobalRate: 1})
+	ctx := context.Background()
+
+	require.NoError(t, limiter.Wait(ctx, "/messages/sendText", url.Values{"chatId": {"chat1"}}))
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx, "/messages/sendText", url.Values{"chatId": {"chat2"}})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// events polling is not limited
+	require.NoError(t, limiter.Wait(ctx, "/events/get", url.Values{}))
+}
+
+func TestClient_Do_RateLimiterBackoff(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	limiter := NewRateLimiter(RateLimiterConfig{ChatRate: 100})
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	client.limiter = limiter
+
+	err := client.SendChatActions("chat1", TypingAction)
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = client.SendChatActionsWithContext(ctx, "chat1", TypingAction)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = client.SendChatActions("chat2", TypingAction)
+	require.NoError(t, err)
+}
@@ -0,0 +1,206 @@
+package botgolang
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 10 * time.Second
+	defaultRetryJitter      = 0.2
+)
+
+// nonIdempotentMethods are API methods which may produce a duplicate if they are re-sent
+// after the server has already processed them.
+var nonIdempotentMethods = map[string]bool{
+	"/messages/sendText":             true,
+	"/messages/sendTextWithDeeplink": true,
+	"/messages/sendFile":             true,
+	"/messages/sendVoice":            true,
+	"/threads/add":                   true,
+}
+
+// RetryPolicy describes how the client retries failed API requests.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// Total number of attempts including the first one.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+
+	// Delay before the first retry, it is doubled for every next attempt
+	BaseDelay time.Duration
+
+	// Upper bound for the delay between attempts
+	MaxDelay time.Duration
+
+	// Fraction of the delay which is randomized, from 0 to 1.
+	// E.g. with Jitter 0.2 the delay of 1s becomes a random value between 0.8s and 1s
+	Jitter float64
+
+	// HTTP statuses which are worth retrying
+	RetryableStatuses []int
+
+	// Retry requests that failed before any response was received (connection reset, timeout, etc.)
+	RetryTransportErrors bool
+
+	// Retry methods that may produce duplicates (sending messages, uploading files, creating threads).
+	// When false, such methods are retried only if API responds with 429 Too Many Requests,
+	// so the request surely was not processed.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and exponential backoff starting from 500ms,
+// which retries rate limiting, server errors and transport errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+		Jitter:      defaultRetryJitter,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryTransportErrors: true,
+	}
+}
+
+// shouldRetry reports whether the failed attempt should be repeated
+// and how long to wait before the next one.
+func (p *RetryPolicy) shouldRetry(attempt int, path string, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	var transportErr *TransportError
+	switch {
+	case errors.As(err, &apiErr):
+		if !p.isRetryableStatus(apiErr.StatusCode) {
+			return 0, false
+		}
+		if nonIdempotentMethods[path] && !p.RetryNonIdempotent && apiErr.StatusCode != http.StatusTooManyRequests {
+			return 0, false
+		}
+
+		delay := p.backoff(attempt)
+		if apiErr.RetryAfter > delay {
+			delay = apiErr.RetryAfter
+		}
+		return delay, true
+	case errors.As(err, &transportErr):
+		if !p.RetryTransportErrors || (nonIdempotentMethods[path] && !p.RetryNonIdempotent) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	return 0, false
+}
+
+func (p *RetryPolicy) isRetryableStatus(code int) bool {
+	for _, status := range p.RetryableStatuses {
+		if status == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns exponential delay for the given attempt number with applied jitter
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// parseRetryAfter parses Retry-After header value given in seconds or as HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// uploadRewinder remembers the initial position of an upload source
+// so the file can be re-sent from the beginning on retry
+type uploadRewinder struct {
+	seeker io.Seeker
+	offset int64
+}
+
+func newUploadRewinder(file UploadFile) *uploadRewinder {
+	if file == nil {
+		return &uploadRewinder{}
+	}
+
+	seeker, ok := uploadSource(file).(io.Seeker)
+	if !ok {
+		return nil
+	}
+
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil
+	}
+
+	return &uploadRewinder{seeker: seeker, offset: offset}
+}
+
+// rewind returns the upload source to its initial position
+func (r *uploadRewinder) rewind() error {
+	if r.seeker == nil {
+		return nil
+	}
+
+	_, err := r.seeker.Seek(r.offset, io.SeekStart)
+	return err
+}
@@ -0,0 +1,255 @@
+package botgolang
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc, policy RetryPolicy) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	client.retryPolicy = policy
+	return client
+}
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	return policy
+}
+
+func TestClient_Do_RetriesRetryableStatus(t *testing.T) {
+	var calls int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}, testRetryPolicy())
+
+	response, err := client.Do("/chats/getInfo", url.Values{}, nil)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":true}`, string(response))
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestClient_Do_StopsAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, testRetryPolicy())
+
+	_, err := client.Do("/chats/getInfo", url.Values{}, nil)
+
+	require.EqualError(t, err, "error status from API: 502 Bad Gateway")
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestClient_Do_NoRetryByDefault(t *testing.T) {
+	var calls int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, RetryPolicy{})
+
+	_, err := client.Do("/chats/getInfo", url.Values{}, nil)
+
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestClient_Do_NonIdempotentMethods(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		policy   func(p *RetryPolicy)
+		expected int32
+	}{
+		{
+			name:     "server_error_is_not_retried",
+			status:   http.StatusInternalServerError,
+			expected: 1,
+		},
+		{
+			name:     "rate_limit_is_retried",
+			status:   http.StatusTooManyRequests,
+			expected: 3,
+		},
+		{
+			name:     "server_error_is_retried_when_allowed",
+			status:   http.StatusInternalServerError,
+			policy:   func(p *RetryPolicy) { p.RetryNonIdempotent = true },
+			expected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := testRetryPolicy()
+			if tt.policy != nil {
+				tt.policy(&policy)
+			}
+
+			var calls int32
+			client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.status)
+			}, policy)
+
+			_, err := client.Do("/messages/sendText", url.Values{}, nil)
+
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestClient_Do_RetryResendsUpload(t *testing.T) {
+	var calls int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "file content", string(content))
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}, testRetryPolicy())
+
+	file := NewUploadFileFromReader("test.txt", strings.NewReader("file content"))
+	_, err := client.Do("/messages/sendFile", url.Values{}, file)
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+// slowSeeker reads slowly and detects seeks made while a read is in progress
+type slowSeeker struct {
+	*strings.Reader
+	reading     int32
+	overlapping int32
+}
+
+func (s *slowSeeker) Read(p []byte) (int, error) {
+	atomic.StoreInt32(&s.reading, 1)
+	defer atomic.StoreInt32(&s.reading, 0)
+	time.Sleep(time.Millisecond)
+	if len(p) > 1024 {
+		p = p[:1024]
+	}
+	return s.Reader.Read(p)
+}
+
+func (s *slowSeeker) Seek(offset int64, whence int) (int64, error) {
+	if atomic.LoadInt32(&s.reading) == 1 {
+		atomic.StoreInt32(&s.overlapping, 1)
+	}
+	return s.Reader.Seek(offset, whence)
+}
+
+func TestClient_Do_RetryRewindsUploadAfterWriterStops(t *testing.T) {
+	content := strings.Repeat("0123456789", 10000)
+
+	var calls int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// the first attempt fails before the body is read
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Connection", "close")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		received, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(received))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}, testRetryPolicy())
+
+	source := &slowSeeker{Reader: strings.NewReader(content)}
+	_, err := client.Do("/messages/sendFile", url.Values{}, NewUploadFileFromReader("test.txt", source))
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	assert.Zero(t, atomic.LoadInt32(&source.overlapping))
+}
+
+func TestClient_Do_NoRetryForNonSeekableUpload(t *testing.T) {
+	var calls int32
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, testRetryPolicy())
+
+	file := NewUploadFileFromReader("test.txt", io.LimitReader(strings.NewReader("file content"), 100))
+	_, err := client.Do("/messages/sendFile", url.Values{}, file)
+
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestClient_Do_RetryStopsOnContextCancel(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.BaseDelay = time.Hour
+	policy.MaxDelay = time.Hour
+
+	client := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.DoWithContext(ctx, "/chats/getInfo", url.Values{}, nil)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, time.Second, policy.backoff(10))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		delay := policy.backoff(2)
+		assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		assert.LessOrEqual(t, delay, 200*time.Millisecond)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("invalid"))
+}
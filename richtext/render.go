@@ -0,0 +1,155 @@
+package richtext
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+type renderer interface {
+	render(b *strings.Builder, n node)
+}
+
+type plainRenderer struct{}
+
+func (plainRenderer) render(b *strings.Builder, n node) {
+	switch n.style {
+	case mention:
+		b.WriteString("@[" + n.text + "]")
+	case link:
+		b.WriteString(n.text)
+		if n.text != n.attr {
+			b.WriteString(" (" + n.attr + ")")
+		}
+	case quote:
+		b.WriteString(prefixLines(n.text, "> "))
+	case orderedList, unorderedList:
+		writeList(b, n, func(s string) string { return s })
+	default:
+		b.WriteString(n.text)
+	}
+}
+
+func writeList(b *strings.Builder, n node, escape func(string) string) {
+	for i, item := range n.items {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if n.style == orderedList {
+			b.WriteString(strconv.Itoa(i+1) + ". ")
+		} else {
+			b.WriteString("- ")
+		}
+		b.WriteString(escape(item))
+	}
+}
+
+func prefixLines(text, prefix string) string {
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
+
+type htmlRenderer struct{}
+
+var htmlTags = map[style]string{
+	bold:      "b",
+	italic:    "i",
+	underline: "u",
+	strike:    "s",
+	code:      "code",
+	quote:     "blockquote",
+}
+
+func (htmlRenderer) render(b *strings.Builder, n node) {
+	text := EscapeHTML(n.text)
+
+	switch n.style {
+	case plain:
+		b.WriteString(text)
+	case pre:
+		if n.attr == "" {
+			b.WriteString("<pre>" + text + "</pre>")
+			return
+		}
+		b.WriteString(`<pre><code class="` + EscapeHTML(n.attr) + `">` + text + "</code></pre>")
+	case link:
+		b.WriteString(`<a href="` + EscapeHTML(n.attr) + `">` + text + "</a>")
+	case mention:
+		b.WriteString("@[" + text + "]")
+	case orderedList, unorderedList:
+		tag := "ul"
+		if n.style == orderedList {
+			tag = "ol"
+		}
+		b.WriteString("<" + tag + ">")
+		for _, item := range n.items {
+			b.WriteString("<li>" + EscapeHTML(item) + "</li>")
+		}
+		b.WriteString("</" + tag + ">")
+	default:
+		tag := htmlTags[n.style]
+		b.WriteString("<" + tag + ">" + text + "</" + tag + ">")
+	}
+}
+
+// EscapeHTML escapes the text to be used in ParseModeHTML messages
+func EscapeHTML(text string) string {
+	return html.EscapeString(text)
+}
+
+type markdownRenderer struct{}
+
+var markdownMarks = map[style]string{
+	bold:      "*",
+	italic:    "_",
+	underline: "__",
+	strike:    "~",
+}
+
+func (markdownRenderer) render(b *strings.Builder, n node) {
+	switch n.style {
+	case plain:
+		b.WriteString(EscapeMarkdownV2(n.text))
+	case code:
+		b.WriteString("`" + escapeMarkdownCode(n.text) + "`")
+	case pre:
+		b.WriteString("```" + escapeMarkdownCode(n.attr) + "\n" + escapeMarkdownCode(n.text) + "\n```")
+	case link:
+		b.WriteString("[" + EscapeMarkdownV2(n.text) + "](" + escapeMarkdownURL(n.attr) + ")")
+	case mention:
+		b.WriteString("@[" + EscapeMarkdownV2(n.text) + "]")
+	case quote:
+		b.WriteString(prefixLines(EscapeMarkdownV2(n.text), ">"))
+	case orderedList, unorderedList:
+		writeList(b, n, EscapeMarkdownV2)
+	default:
+		mark := markdownMarks[n.style]
+		b.WriteString(mark + EscapeMarkdownV2(n.text) + mark)
+	}
+}
+
+var (
+	markdownReplacer     = newEscapeReplacer("\\_*[]()~`>#+-=|{}.!")
+	markdownCodeReplacer = newEscapeReplacer("\\`")
+	markdownURLReplacer  = newEscapeReplacer("\\)")
+)
+
+func newEscapeReplacer(chars string) *strings.Replacer {
+	var pairs []string
+	for _, c := range chars {
+		pairs = append(pairs, string(c), "\\"+string(c))
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// EscapeMarkdownV2 escapes the text to be used in ParseModeMarkdownV2 messages
+func EscapeMarkdownV2(text string) string {
+	return markdownReplacer.Replace(text)
+}
+
+func escapeMarkdownCode(text string) string {
+	return markdownCodeReplacer.Replace(text)
+}
+
+func escapeMarkdownURL(url string) string {
+	return markdownURLReplacer.Replace(url)
+}
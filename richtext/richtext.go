@@ -0,0 +1,175 @@
+// Package richtext builds formatted texts of messages.
+// Texts are rendered for ParseModeHTML or ParseModeMarkdownV2 with all strings escaped,
+// so user input cannot break the formatting.
+package richtext
+
+import (
+	"strings"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+type style int
+
+const (
+	plain style = iota
+	bold
+	italic
+	underline
+	strike
+	code
+	pre
+	link
+	mention
+	quote
+	orderedList
+	unorderedList
+)
+
+type node struct {
+	style style
+	text  string
+
+	// language of pre, url of link
+	attr  string
+	items []string
+}
+
+// block reports whether the node must be on separate lines
+func (n node) block() bool {
+	switch n.style {
+	case pre, quote, orderedList, unorderedList:
+		return true
+	}
+	return false
+}
+
+// Text is a builder of formatted text, the zero value is an empty text
+type Text struct {
+	nodes []node
+}
+
+// New returns an empty text
+func New() *Text {
+	return &Text{}
+}
+
+func (t *Text) add(n node) *Text {
+	t.nodes = append(t.nodes, n)
+	return t
+}
+
+// Plain appends the text without formatting
+func (t *Text) Plain(text string) *Text {
+	return t.add(node{style: plain, text: text})
+}
+
+// Line appends a line break
+func (t *Text) Line() *Text {
+	return t.Plain("\n")
+}
+
+// Bold appends bold text
+func (t *Text) Bold(text string) *Text {
+	return t.add(node{style: bold, text: text})
+}
+
+// Italic appends italic text
+func (t *Text) Italic(text string) *Text {
+	return t.add(node{style: italic, text: text})
+}
+
+// Underline appends underlined text
+func (t *Text) Underline(text string) *Text {
+	return t.add(node{style: underline, text: text})
+}
+
+// Strike appends strikethrough text
+func (t *Text) Strike(text string) *Text {
+	return t.add(node{style: strike, text: text})
+}
+
+// Code appends inline code
+func (t *Text) Code(text string) *Text {
+	return t.add(node{style: code, text: text})
+}
+
+// Pre appends a block of code, the language may be empty
+func (t *Text) Pre(text, language string) *Text {
+	return t.add(node{style: pre, text: text, attr: language})
+}
+
+// Link appends the text linked to the url
+func (t *Text) Link(text, url string) *Text {
+	return t.add(node{style: link, text: text, attr: url})
+}
+
+// Mention appends the mention of the user or chat
+func (t *Text) Mention(id string) *Text {
+	return t.add(node{style: mention, text: id})
+}
+
+// Quote appends a quote block
+func (t *Text) Quote(text string) *Text {
+	return t.add(node{style: quote, text: text})
+}
+
+// OrderedList appends a numbered list
+func (t *Text) OrderedList(items ...string) *Text {
+	return t.add(node{style: orderedList, items: items})
+}
+
+// UnorderedList appends a bulleted list
+func (t *Text) UnorderedList(items ...string) *Text {
+	return t.add(node{style: unorderedList, items: items})
+}
+
+// Render returns the text for the parse mode, formatting is dropped for the empty mode
+func (t *Text) Render(mode botgolang.ParseMode) string {
+	var r renderer
+	switch mode {
+	case botgolang.ParseModeHTML:
+		r = htmlRenderer{}
+	case botgolang.ParseModeMarkdownV2:
+		r = markdownRenderer{}
+	default:
+		r = plainRenderer{}
+	}
+
+	var b strings.Builder
+	for i, n := range t.nodes {
+		// HTML blocks are separated by tags
+		separate := mode != botgolang.ParseModeHTML
+		if separate && n.block() && b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+		r.render(&b, n)
+		if separate && n.block() && i < len(t.nodes)-1 && !strings.HasPrefix(t.nodes[i+1].text, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// HTML returns the text for ParseModeHTML
+func (t *Text) HTML() string {
+	return t.Render(botgolang.ParseModeHTML)
+}
+
+// MarkdownV2 returns the text for ParseModeMarkdownV2
+func (t *Text) MarkdownV2() string {
+	return t.Render(botgolang.ParseModeMarkdownV2)
+}
+
+// String returns the text without formatting
+func (t *Text) String() string {
+	return t.Render("")
+}
+
+// Apply sets the text and the parse mode of the message.
+// It works for texts, captions of files and edited messages.
+func (t *Text) Apply(message *botgolang.Message, mode botgolang.ParseMode) *botgolang.Message {
+	message.Text = t.Render(mode)
+	message.ParseMode = mode
+	return message
+}
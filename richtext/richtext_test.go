@@ -0,0 +1,67 @@
+package richtext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+func TestText_HTML(t *testing.T) {
+	text := New().
+		Bold("Alert").Plain(" from <script>&").Line().
+		Italic("i").Underline("u").Strike("s").Code("a<b").Line().
+		Link("dash\"board", `https://example.com/?a=1&b="2"`).Plain(" ").Mention("user@corp").
+		Pre("if a < b {}", "go").
+		Quote("quoted").
+		OrderedList("one", "<two>").
+		UnorderedList("x")
+
+	assert.Equal(t,
+		"<b>Alert</b> from &lt;script&gt;&amp;\n"+
+			"<i>i</i><u>u</u><s>s</s><code>a&lt;b</code>\n"+
+			`<a href="https://example.com/?a=1&amp;b=&#34;2&#34;">dash&#34;board</a> @[user@corp]`+
+			`<pre><code class="go">if a &lt; b {}</code></pre>`+
+			"<blockquote>quoted</blockquote>"+
+			"<ol><li>one</li><li>&lt;two&gt;</li></ol>"+
+			"<ul><li>x</li></ul>",
+		text.HTML())
+}
+
+func TestText_MarkdownV2(t *testing.T) {
+	text := New().
+		Bold("1+1=2!").Plain(" (see_this)").Line().
+		Italic("i").Plain(" ").Underline("u").Plain(" ").Strike("s").Plain(" ").Code("`x`\\").
+		Link("docs.", "https://example.com/a_(b)").Plain(" ").Mention("first.last@corp").
+		Pre("fmt.Println(`*`)", "go").
+		Quote("line 1.\nline 2").
+		OrderedList("first.", "second").
+		Plain("done").
+		UnorderedList("-x-")
+
+	assert.Equal(t,
+		"*1\\+1\\=2\\!* \\(see\\_this\\)\n"+
+			"_i_ __u__ ~s~ `\\`x\\`\\\\`"+
+			"[docs\\.](https://example.com/a_(b\\)) @[first\\.last@corp]\n"+
+			"```go\nfmt.Println(\\`*\\`)\n```\n"+
+			">line 1\\.\n>line 2\n"+
+			"1. first\\.\n2. second\n"+
+			"done\n"+
+			"- \\-x\\-",
+		text.MarkdownV2())
+}
+
+func TestText_Plain(t *testing.T) {
+	text := New().Bold("Hi").Plain(", ").Link("site", "https://example.com").Quote("q").UnorderedList("a", "b")
+
+	assert.Equal(t, "Hi, site (https://example.com)\n> q\n- a\n- b", text.String())
+}
+
+func TestText_Apply(t *testing.T) {
+	message := &botgolang.Message{}
+	New().Bold("*").Apply(message, botgolang.ParseModeMarkdownV2)
+
+	assert.Equal(t, "*\\**", message.Text)
+	assert.Equal(t, botgolang.ParseModeMarkdownV2, message.ParseMode)
+}
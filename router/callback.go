@@ -0,0 +1,158 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+// CallbackHandler handles a callback query
+type CallbackHandler func(ctx context.Context, cb *Callback) error
+
+// Callback is a callback query of the pressed button matched by a pattern
+type Callback struct {
+	// Pattern which matched CallbackData of the button
+	Pattern string
+
+	// Params extracted from CallbackData by the pattern
+	Params map[string]string
+
+	// Event with the callback query
+	Event botgolang.Event
+
+	// Response to the query, the handler may fill and send it,
+	// otherwise the router sends an empty response
+	Response *botgolang.ButtonResponse
+
+	// Message with the pressed button
+	Message *botgolang.Message
+}
+
+// Param returns the param or an empty string if the pattern has no such param
+func (c *Callback) Param(name string) string {
+	return c.Params[name]
+}
+
+// Int returns the param as a number
+func (c *Callback) Int(name string) (int, error) {
+	param, ok := c.Params[name]
+	if !ok {
+		return 0, fmt.Errorf("param %s: %w", name, ErrMissingArgument)
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil {
+		return 0, fmt.Errorf("param %s %q is not a number: %w", name, param, ErrInvalidArgument)
+	}
+	return value, nil
+}
+
+type callbackRoute struct {
+	pattern  string
+	segments []string
+	handler  CallbackHandler
+}
+
+// match returns params of the data if it matches the pattern.
+// Segment :name matches any segment, *name matches one or more remaining segments.
+func (r *callbackRoute) match(data string) (map[string]string, bool) {
+	parts := strings.Split(data, "/")
+	params := make(map[string]string)
+
+	for i, segment := range r.segments {
+		if i >= len(parts) {
+			return nil, false
+		}
+
+		if strings.HasPrefix(segment, "*") {
+			params[segment[1:]] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+		if strings.HasPrefix(segment, ":") {
+			params[segment[1:]] = parts[i]
+		} else if segment != parts[i] {
+			return nil, false
+		}
+	}
+
+	return params, len(parts) == len(r.segments)
+}
+
+// CallbackRouter routes callback queries to handlers by CallbackData of the pressed button,
+// it implements botgolang.Handler.
+// Every query is answered: if the handler does not send the response, the router sends an empty one.
+type CallbackRouter struct {
+	routes   []*callbackRoute
+	unknown  CallbackHandler
+	fallback botgolang.Handler
+}
+
+// NewCallbackRouter returns new callback router
+func NewCallbackRouter() *CallbackRouter {
+	return &CallbackRouter{}
+}
+
+// Callback registers the handler of CallbackData matching the pattern.
+// Pattern segments are separated by slash, :name matches one segment and *name matches the rest,
+// e.g. vote/:pollId/:option. The first registered matching pattern is used.
+func (r *CallbackRouter) Callback(pattern string, handler CallbackHandler) {
+	r.routes = append(r.routes, &callbackRoute{
+		pattern:  pattern,
+		segments: strings.Split(pattern, "/"),
+		handler:  handler,
+	})
+}
+
+// Unknown sets the handler of CallbackData which matches no pattern
+func (r *CallbackRouter) Unknown(handler CallbackHandler) {
+	r.unknown = handler
+}
+
+// Fallback sets the handler of events which are not callback queries
+func (r *CallbackRouter) Fallback(handler botgolang.Handler) {
+	r.fallback = handler
+}
+
+// Handle calls the handler of the callback query and makes sure the query is answered
+func (r *CallbackRouter) Handle(ctx context.Context, event botgolang.Event) (err error) {
+	if event.Type != botgolang.CALLBACK_QUERY {
+		if r.fallback != nil {
+			return r.fallback.Handle(ctx, event)
+		}
+		return nil
+	}
+
+	cb := &Callback{
+		Event:    event,
+		Response: event.Payload.CallbackQuery(),
+		Message:  event.Payload.CallbackMessage(),
+	}
+
+	// the answer is sent even if the handler panics
+	defer func() {
+		if cb.Response.Answered() {
+			return
+		}
+		if answerErr := cb.Response.SendWithContext(ctx); answerErr != nil && err == nil {
+			err = fmt.Errorf("cannot answer callback query: %w", answerErr)
+		}
+	}()
+
+	handler := r.unknown
+	for _, route := range r.routes {
+		if params, ok := route.match(cb.Response.CallbackData); ok {
+			cb.Pattern = route.pattern
+			cb.Params = params
+			handler = route.handler
+			break
+		}
+	}
+
+	if handler == nil {
+		return nil
+	}
+	return handler(ctx, cb)
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mail-ru-im/bot-golang/internal/bottest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackRoute_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		data    string
+		params  map[string]string
+		ok      bool
+	}{
+		{pattern: "vote/:pollId/:option", data: "vote/42/yes", params: map[string]string{"pollId": "42", "option": "yes"}, ok: true},
+		{pattern: "vote/:pollId/:option", data: "vote/42", ok: false},
+		{pattern: "vote/:pollId/:option", data: "vote/42/yes/no", ok: false},
+		{pattern: "vote/:pollId/:option", data: "poll/42/yes", ok: false},
+		{pattern: "echo", data: "echo", params: map[string]string{}, ok: true},
+		{pattern: "page/*rest", data: "page/users/3", params: map[string]string{"rest": "users/3"}, ok: true},
+		{pattern: "page/*rest", data: "page", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.data, func(t *testing.T) {
+			router := NewCallbackRouter()
+			router.Callback(tt.pattern, nil)
+			route := router.routes[0]
+
+			params, ok := route.match(tt.data)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.params, params)
+			}
+		})
+	}
+}
+
+func TestCallbackRouter_Handle(t *testing.T) {
+	api := bottest.NewAPI(t)
+	router := NewCallbackRouter()
+
+	var votes []int
+	router.Callback("vote/:pollId/:option", func(ctx context.Context, cb *Callback) error {
+		pollID, err := cb.Int("pollId")
+		if err != nil {
+			return err
+		}
+		votes = append(votes, pollID)
+
+		cb.Response.Text = "Voted for " + cb.Param("option")
+		return cb.Response.SendWithContext(ctx)
+	})
+	router.Callback("echo", func(ctx context.Context, cb *Callback) error {
+		return nil
+	})
+	router.Callback("fail", func(ctx context.Context, cb *Callback) error {
+		return errors.New("failure")
+	})
+	router.Callback("panic", func(ctx context.Context, cb *Callback) error {
+		panic("boom")
+	})
+
+	receive := func(data string) error {
+		event := api.Receive(`{"eventId":1,"type":"callbackQuery","payload":{"queryId":"q-` + data + `","callbackData":"` + data + `","message":{"msgId":"1"}}}`)
+		return router.Handle(context.Background(), event)
+	}
+
+	require.NoError(t, receive("vote/42/yes"))
+	require.NoError(t, receive("echo"))
+	require.NoError(t, receive("unknown"))
+	require.EqualError(t, receive("fail"), "failure")
+	require.Panics(t, func() {
+		_ = receive("panic")
+	})
+	_, err := (&Callback{Params: map[string]string{"id": "x"}}).Int("id")
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	assert.Equal(t, []int{42}, votes)
+
+	sent := api.Sent()
+	require.Len(t, sent, 5)
+	for i, data := range []string{"vote/42/yes", "echo", "unknown", "fail", "panic"} {
+		assert.Equal(t, "/messages/answerCallbackQuery", sent[i].Path)
+		assert.Equal(t, "q-"+data, sent[i].Params.Get("queryId"))
+	}
+	assert.Equal(t, "Voted for yes", sent[0].Params.Get("text"))
+	assert.Equal(t, "", sent[1].Params.Get("text"))
+}
@@ -0,0 +1,271 @@
+// Package router routes events received by the bot to handlers:
+// commands of new messages and callback queries of buttons.
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+)
+
+var (
+	// ErrMissingArgument is returned when the command has fewer arguments than requested
+	ErrMissingArgument = errors.New("missing argument")
+
+	// ErrInvalidArgument is returned when the argument cannot be converted to the requested type
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// CommandHandler handles a command
+type CommandHandler func(ctx context.Context, cmd *Command) error
+
+// Command is a command parsed from the text of new message, e.g. /remind 10m "call Bob"
+type Command struct {
+	// Registered name of the command without slash
+	Name string
+
+	// Name or alias used in the message
+	Alias string
+
+	// Arguments of the command
+	Args []string
+
+	// Event with the command
+	Event botgolang.Event
+
+	// Message with the command, replies can be sent with it
+	Message *botgolang.Message
+}
+
+// Arg returns the argument or an empty string if there is no such argument
+func (c *Command) Arg(i int) string {
+	if i < 0 || i >= len(c.Args) {
+		return ""
+	}
+	return c.Args[i]
+}
+
+func (c *Command) arg(i int) (string, error) {
+	if i < 0 || i >= len(c.Args) {
+		return "", fmt.Errorf("argument %d: %w", i+1, ErrMissingArgument)
+	}
+	return c.Args[i], nil
+}
+
+// Int returns the argument as a number
+func (c *Command) Int(i int) (int, error) {
+	arg, err := c.arg(i)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("argument %d %q is not a number: %w", i+1, arg, ErrInvalidArgument)
+	}
+	return value, nil
+}
+
+// Duration returns the argument as a duration, e.g. 90s or 1h30m
+func (c *Command) Duration(i int) (time.Duration, error) {
+	arg, err := c.arg(i)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := time.ParseDuration(arg)
+	if err != nil {
+		return 0, fmt.Errorf("argument %d %q is not a duration: %w", i+1, arg, ErrInvalidArgument)
+	}
+	return value, nil
+}
+
+// Mention returns the user mentioned by the argument.
+// Mentions are written in the text as @[userId], names are taken from MENTION parts of the message.
+func (c *Command) Mention(i int) (botgolang.Contact, error) {
+	arg, err := c.arg(i)
+	if err != nil {
+		return botgolang.Contact{}, err
+	}
+
+	if !strings.HasPrefix(arg, "@[") || !strings.HasSuffix(arg, "]") || len(arg) < 4 {
+		return botgolang.Contact{}, fmt.Errorf("argument %d %q is not a mention: %w", i+1, arg, ErrInvalidArgument)
+	}
+
+	userID := arg[2 : len(arg)-1]
+	for _, part := range c.Event.Payload.Parts {
+		if part.Type == botgolang.MENTION && part.Payload.UserID == userID {
+			return botgolang.Contact{
+				User:      botgolang.User{ID: userID},
+				FirstName: part.Payload.FirstName,
+				LastName:  part.Payload.LastName,
+			}, nil
+		}
+	}
+	return botgolang.Contact{User: botgolang.User{ID: userID}}, nil
+}
+
+// Bind converts the arguments in order to the targets,
+// supported targets are *string, *int, *time.Duration and *botgolang.Contact for mentions
+func (c *Command) Bind(targets ...interface{}) error {
+	for i, target := range targets {
+		var err error
+		switch target := target.(type) {
+		case *string:
+			*target, err = c.arg(i)
+		case *int:
+			*target, err = c.Int(i)
+		case *time.Duration:
+			*target, err = c.Duration(i)
+		case *botgolang.Contact:
+			*target, err = c.Mention(i)
+		default:
+			err = fmt.Errorf("unsupported target %T of argument %d", target, i+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type commandRoute struct {
+	name        string
+	description string
+	aliases     []string
+	handler     CommandHandler
+}
+
+// CommandRouter routes commands of new messages to handlers, it implements botgolang.Handler.
+// Command /help is answered with the list of commands unless the router has its own help command.
+type CommandRouter struct {
+	nick     string
+	routes   []*commandRoute
+	names    map[string]*commandRoute
+	unknown  CommandHandler
+	fallback botgolang.Handler
+}
+
+// NewCommandRouter returns new command router.
+// Commands addressed to another bot, like /start@otherbot, are ignored if nick of the bot is given,
+// usually it is Bot.Info.Nick.
+func NewCommandRouter(nick string) *CommandRouter {
+	return &CommandRouter{
+		nick:  strings.TrimPrefix(nick, "@"),
+		names: make(map[string]*commandRoute),
+	}
+}
+
+// Command registers the handler of the command with name and aliases without slash.
+// Names are case-insensitive, registering the same name twice panics.
+func (r *CommandRouter) Command(name, description string, handler CommandHandler, aliases ...string) {
+	route := &commandRoute{
+		name:        name,
+		description: description,
+		aliases:     aliases,
+		handler:     handler,
+	}
+
+	for _, key := range append([]string{name}, aliases...) {
+		key = strings.ToLower(key)
+		if _, ok := r.names[key]; ok {
+			panic("router: command /" + key + " is already registered")
+		}
+		r.names[key] = route
+	}
+	r.routes = append(r.routes, route)
+}
+
+// Unknown sets the handler of commands which are not registered,
+// by default the router replies with a hint to use /help
+func (r *CommandRouter) Unknown(handler CommandHandler) {
+	r.unknown = handler
+}
+
+// Fallback sets the handler of events which are not commands
+func (r *CommandRouter) Fallback(handler botgolang.Handler) {
+	r.fallback = handler
+}
+
+// Help returns the list of commands with their descriptions
+func (r *CommandRouter) Help() string {
+	var help strings.Builder
+	for _, route := range r.routes {
+		help.WriteString("/" + route.name)
+		for _, alias := range route.aliases {
+			help.WriteString(", /" + alias)
+		}
+		if route.description != "" {
+			help.WriteString(" - " + route.description)
+		}
+		help.WriteString("\n")
+	}
+	if _, ok := r.names["help"]; !ok {
+		help.WriteString("/help - Show this help\n")
+	}
+	return strings.TrimSuffix(help.String(), "\n")
+}
+
+// Handle calls the handler of the command in new message
+func (r *CommandRouter) Handle(ctx context.Context, event botgolang.Event) error {
+	cmd, ok := r.parse(event)
+	if !ok {
+		if r.fallback != nil {
+			return r.fallback.Handle(ctx, event)
+		}
+		return nil
+	}
+
+	if route, ok := r.names[strings.ToLower(cmd.Alias)]; ok {
+		cmd.Name = route.name
+		return route.handler(ctx, cmd)
+	}
+
+	if strings.EqualFold(cmd.Alias, "help") {
+		cmd.Name = "help"
+		return cmd.Message.ReplyWithContext(ctx, r.Help())
+	}
+
+	if r.unknown != nil {
+		return r.unknown(ctx, cmd)
+	}
+	return cmd.Message.ReplyWithContext(ctx, fmt.Sprintf("Unknown command /%s, see /help", cmd.Alias))
+}
+
+// parse returns the command of new message addressed to the bot
+func (r *CommandRouter) parse(event botgolang.Event) (*Command, bool) {
+	if event.Type != botgolang.NEW_MESSAGE {
+		return nil, false
+	}
+
+	text := strings.TrimSpace(event.Payload.Text)
+	if !strings.HasPrefix(text, "/") {
+		return nil, false
+	}
+
+	head, rest := text[1:], ""
+	if i := strings.IndexFunc(head, unicode.IsSpace); i >= 0 {
+		head, rest = head[:i], head[i:]
+	}
+
+	alias, nick, addressed := strings.Cut(head, "@")
+	if alias == "" {
+		return nil, false
+	}
+	if addressed && r.nick != "" && !strings.EqualFold(nick, r.nick) {
+		return nil, false
+	}
+
+	return &Command{
+		Alias:   alias,
+		Args:    tokenize(rest),
+		Event:   event,
+		Message: event.Payload.Message(),
+	}, true
+}
@@ -0,0 +1,165 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	botgolang "github.com/mail-ru-im/bot-golang"
+	"github.com/mail-ru-im/bot-golang/internal/bottest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		args []string
+	}{
+		{text: "", args: nil},
+		{text: "  one two\tthree ", args: []string{"one", "two", "three"}},
+		{text: `"call Bob" 'at home' x`, args: []string{"call Bob", "at home", "x"}},
+		{text: `say "\"hi\"" it\'s`, args: []string{"say", `"hi"`, "it's"}},
+		{text: `10m don't forget`, args: []string{"10m", "don't", "forget"}},
+		{text: `it's 'quoted text'`, args: []string{"it's", "quoted text"}},
+		{text: `'a\b' ""`, args: []string{`a\b`, ""}},
+		{text: `"unterminated quote`, args: []string{"unterminated quote"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.args, tokenize(tt.text))
+		})
+	}
+}
+
+func newTestMessageEvent(text string, parts ...botgolang.Part) botgolang.Event {
+	return botgolang.Event{
+		Type: botgolang.NEW_MESSAGE,
+		Payload: botgolang.EventPayload{
+			BaseEventPayload: botgolang.BaseEventPayload{Text: text},
+			Parts:            parts,
+		},
+	}
+}
+
+func TestCommandRouter_Handle(t *testing.T) {
+	router := NewCommandRouter("@testbot")
+
+	var got *Command
+	router.Command("remind", "Remind about something", func(ctx context.Context, cmd *Command) error {
+		got = cmd
+		return nil
+	}, "r")
+
+	tests := []struct {
+		name  string
+		text  string
+		alias string
+		args  []string
+	}{
+		{name: "command", text: `/remind 10m "call Bob"`, alias: "remind", args: []string{"10m", "call Bob"}},
+		{name: "alias", text: "/R 1h", alias: "R", args: []string{"1h"}},
+		{name: "nick", text: "/remind@TestBot", alias: "remind"},
+		{name: "other_bot", text: "/remind@otherbot 10m"},
+		{name: "not_command", text: "remind 10m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			require.NoError(t, router.Handle(context.Background(), newTestMessageEvent(tt.text)))
+
+			if tt.alias == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, "remind", got.Name)
+			assert.Equal(t, tt.alias, got.Alias)
+			assert.Equal(t, tt.args, got.Args)
+		})
+	}
+
+	assert.Panics(t, func() {
+		router.Command("other", "", nil, "remind")
+	})
+}
+
+func TestCommand_Bind(t *testing.T) {
+	cmd := &Command{
+		Args: []string{"ban", "@[bob@corp.ru]", "3", "1h30m", "@[alice@corp.ru]"},
+		Event: newTestMessageEvent("", botgolang.Part{
+			Type:    botgolang.MENTION,
+			Payload: botgolang.PartPayload{UserID: "bob@corp.ru", FirstName: "Bob"},
+		}),
+	}
+
+	var action string
+	var user botgolang.Contact
+	var times int
+	var period time.Duration
+	require.NoError(t, cmd.Bind(&action, &user, &times, &period))
+
+	assert.Equal(t, "ban", action)
+	assert.Equal(t, "bob@corp.ru", user.ID)
+	assert.Equal(t, "Bob", user.FirstName)
+	assert.Equal(t, 3, times)
+	assert.Equal(t, 90*time.Minute, period)
+
+	mention, err := cmd.Mention(4)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@corp.ru", mention.ID)
+
+	_, err = cmd.Int(0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = cmd.Duration(2)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = cmd.Mention(0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = cmd.Int(5)
+	assert.ErrorIs(t, err, ErrMissingArgument)
+	assert.Equal(t, "", cmd.Arg(5))
+}
+
+func TestCommandRouter_HelpAndUnknown(t *testing.T) {
+	api := bottest.NewAPI(t)
+	router := NewCommandRouter("")
+	router.Command("start", "Start the bot", func(ctx context.Context, cmd *Command) error {
+		return nil
+	})
+	router.Command("vote", "Vote for the option", func(ctx context.Context, cmd *Command) error {
+		return nil
+	}, "v")
+
+	assert.Equal(t, "/start - Start the bot\n/vote, /v - Vote for the option\n/help - Show this help", router.Help())
+
+	event := api.Receive(`{"eventId":1,"type":"newMessage","payload":{"msgId":"1","text":"/help","chat":{"chatId":"chat"}}}`)
+	require.NoError(t, router.Handle(context.Background(), event))
+
+	event = api.Receive(`{"eventId":2,"type":"newMessage","payload":{"msgId":"2","text":"/stop now","chat":{"chatId":"chat"}}}`)
+	require.NoError(t, router.Handle(context.Background(), event))
+
+	sent := api.Sent()
+	require.Len(t, sent, 2)
+	assert.Equal(t, "/messages/sendText", sent[0].Path)
+	assert.Equal(t, router.Help(), sent[0].Params.Get("text"))
+	assert.Equal(t, "1", sent[0].Params.Get("replyMsgId"))
+	assert.Equal(t, "Unknown command /stop, see /help", sent[1].Params.Get("text"))
+
+	var unknown string
+	router.Unknown(func(ctx context.Context, cmd *Command) error {
+		unknown = cmd.Alias
+		return nil
+	})
+	var fallback bool
+	router.Fallback(botgolang.HandlerFunc(func(ctx context.Context, event botgolang.Event) error {
+		fallback = true
+		return nil
+	}))
+
+	require.NoError(t, router.Handle(context.Background(), newTestMessageEvent("/stop")))
+	require.NoError(t, router.Handle(context.Background(), newTestMessageEvent("hello")))
+	assert.Equal(t, "stop", unknown)
+	assert.True(t, fallback)
+}
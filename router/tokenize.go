@@ -0,0 +1,53 @@
+package router
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenize splits the text into arguments by whitespace.
+// Arguments can be quoted with double or single quotes to contain spaces, a single quote
+// opens a quote only at the start of an argument, so apostrophes in words are kept as is.
+// Backslash escapes the next character inside double quotes and outside of quotes.
+// An unterminated quote takes the rest of the text.
+func tokenize(text string) []string {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range text {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '"' || r == '\'' && !inArg:
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
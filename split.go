@@ -0,0 +1,371 @@
+package botgolang
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxTextLength is the length of text in UTF-16 code units after which SendSplit splits the message
+const DefaultMaxTextLength = 4096
+
+type breakKind int
+
+const (
+	noBreak breakKind = iota
+	wordBreak
+	lineBreak
+	paragraphBreak
+)
+
+// markupTag is an open entity of the markup, it is closed at the end of a chunk and opened again in the next one
+type markupTag struct {
+	name  string
+	open  string
+	close string
+}
+
+// splitPoint is a position in the text where it can be split
+type splitPoint struct {
+	pos   int
+	units int
+	kind  breakKind
+	state []markupTag
+}
+
+// markupScanner reads the text by tokens which cannot be split: runes, escapes, tags, entities and links
+type markupScanner struct {
+	text  string
+	mode  ParseMode
+	pos   int
+	units int
+	state []markupTag
+
+	// the previous rune was a line break
+	newline bool
+}
+
+// next reads the token and returns the kind of break after it
+func (s *markupScanner) next() breakKind {
+	start := s.pos
+	kind := noBreak
+
+	switch s.mode {
+	case ParseModeHTML:
+		s.nextHTML()
+	case ParseModeMarkdownV2:
+		s.nextMarkdown()
+	default:
+		_, size := utf8.DecodeRuneInString(s.text[s.pos:])
+		s.pos += size
+	}
+
+	token := s.text[start:s.pos]
+	s.units += UTF16Len(token)
+
+	switch token {
+	case "\n":
+		kind = lineBreak
+		if s.newline {
+			kind = paragraphBreak
+		}
+		s.newline = true
+		return kind
+	case " ", "\t":
+		kind = wordBreak
+	}
+	s.newline = false
+	return kind
+}
+
+func (s *markupScanner) nextHTML() {
+	rest := s.text[s.pos:]
+	switch rest[0] {
+	case '<':
+		end := strings.IndexByte(rest, '>')
+		if end < 0 {
+			break
+		}
+		s.pos += end + 1
+		s.htmlTag(rest[:end+1])
+		return
+	case '&':
+		end := strings.IndexByte(rest, ';')
+		if end > 0 && end <= 10 {
+			s.pos += end + 1
+			return
+		}
+	}
+
+	_, size := utf8.DecodeRuneInString(rest)
+	s.pos += size
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+func (s *markupScanner) htmlTag(tag string) {
+	body := strings.Trim(tag, "<>/ ")
+	name := strings.ToLower(strings.Fields(body + " ")[0])
+
+	switch {
+	case strings.HasPrefix(tag, "</"):
+		for i := len(s.state) - 1; i >= 0; i-- {
+			if s.state[i].name == name {
+				s.state = s.state[:i]
+				break
+			}
+		}
+	case strings.HasSuffix(tag, "/>") || voidTags[name]:
+	default:
+		s.state = append(s.state, markupTag{name: name, open: tag, close: "</" + name + ">"})
+	}
+}
+
+func (s *markupScanner) nextMarkdown() {
+	rest := s.text[s.pos:]
+	top := ""
+	if len(s.state) > 0 {
+		top = s.state[len(s.state)-1].name
+	}
+
+	switch {
+	case rest[0] == '\\' && len(rest) > 1:
+		_, size := utf8.DecodeRuneInString(rest[1:])
+		s.pos += 1 + size
+		return
+	case strings.HasPrefix(rest, "```"):
+		if top == "```" {
+			s.state = s.state[:len(s.state)-1]
+			s.pos += 3
+			return
+		}
+		if top != "`" {
+			// the language is a part of the opening token
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			s.pos += end + 1
+			s.state = append(s.state, markupTag{name: "```", open: rest[:end+1], close: "\n```"})
+			return
+		}
+	case top == "```":
+	case rest[0] == '`':
+		s.toggleMarkdown("`")
+		s.pos++
+		return
+	case top == "`":
+	case rest[0] == '[':
+		if end := markdownLinkEnd(rest); end > 0 {
+			s.pos += end
+			return
+		}
+	case strings.HasPrefix(rest, "__"):
+		s.toggleMarkdown("__")
+		s.pos += 2
+		return
+	case rest[0] == '*' || rest[0] == '_' || rest[0] == '~':
+		s.toggleMarkdown(rest[:1])
+		s.pos++
+		return
+	}
+
+	_, size := utf8.DecodeRuneInString(rest)
+	s.pos += size
+}
+
+// toggleMarkdown opens the entity or closes it if it is open
+func (s *markupScanner) toggleMarkdown(marker string) {
+	for i := len(s.state) - 1; i >= 0; i-- {
+		if s.state[i].name == marker {
+			s.state = s.state[:i]
+			return
+		}
+	}
+	s.state = append(s.state, markupTag{name: marker, open: marker, close: marker})
+}
+
+// markdownLinkEnd returns the length of the link at the start of the text or zero if there is no link
+func markdownLinkEnd(text string) int {
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '\n':
+			return 0
+		case ']':
+			if i+1 >= len(text) || text[i+1] != '(' {
+				return 0
+			}
+			for j := i + 2; j < len(text); j++ {
+				switch text[j] {
+				case '\\':
+					j++
+				case ')':
+					return j + 1
+				}
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+func (s *markupScanner) point(kind breakKind) splitPoint {
+	return splitPoint{
+		pos:   s.pos,
+		units: s.units,
+		kind:  kind,
+		state: append([]markupTag(nil), s.state...),
+	}
+}
+
+func openLength(state []markupTag) int {
+	length := 0
+	for _, tag := range state {
+		length += UTF16Len(tag.open)
+	}
+	return length
+}
+
+func closeLength(state []markupTag) int {
+	length := 0
+	for _, tag := range state {
+		length += UTF16Len(tag.close)
+	}
+	return length
+}
+
+// renderChunk returns the part of the text with entities open at its start and end closed
+func renderChunk(open []markupTag, text string, close []markupTag) string {
+	var b strings.Builder
+	for _, tag := range open {
+		b.WriteString(tag.open)
+	}
+	b.WriteString(strings.TrimRight(text, " \t\n"))
+	for i := len(close) - 1; i >= 0; i-- {
+		b.WriteString(close[i].close)
+	}
+	return b.String()
+}
+
+// chooseSplit returns the point to split at: the strongest break in the second half of the chunk,
+// the last break or the last position between tokens if there are no breaks
+func chooseSplit(candidates []splitPoint, last splitPoint, startUnits, limit int) splitPoint {
+	for kind := paragraphBreak; kind > noBreak; kind-- {
+		for i := len(candidates) - 1; i >= 0; i-- {
+			if candidates[i].kind == kind && candidates[i].units-startUnits >= limit/2 {
+				return candidates[i]
+			}
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[len(candidates)-1]
+	}
+	return last
+}
+
+// SplitText splits the text into chunks not longer than limit UTF-16 code units.
+// The text is split on paragraph, line or word boundaries, HTML tags, Markdown entities and
+// code blocks open at the boundary are closed at the end of the chunk and opened again in the next one.
+// A token which doesn't fit the limit alone, e.g. a long link, is left in an oversized chunk.
+func SplitText(text string, mode ParseMode, limit int) []string {
+	if limit <= 0 || UTF16Len(text) <= limit {
+		return []string{text}
+	}
+
+	s := &markupScanner{text: text, mode: mode}
+	start := s.point(noBreak)
+	var candidates []splitPoint
+	var chunks []string
+
+	for s.pos < len(s.text) {
+		last := s.point(noBreak)
+		kind := s.next()
+
+		// whitespace at the end of a chunk is trimmed, so it doesn't count
+		for kind == noBreak && last.pos > start.pos && openLength(start.state)+s.units-start.units+closeLength(s.state) > limit {
+			cut := chooseSplit(candidates, last, start.units, limit)
+			if strings.TrimSpace(text[start.pos:cut.pos]) != "" {
+				chunks = append(chunks, renderChunk(start.state, text[start.pos:cut.pos], cut.state))
+			}
+			start = cut
+
+			var rest []splitPoint
+			for _, candidate := range candidates {
+				if candidate.pos > cut.pos {
+					rest = append(rest, candidate)
+				}
+			}
+			candidates = rest
+		}
+
+		if kind != noBreak {
+			candidates = append(candidates, s.point(kind))
+		}
+	}
+
+	if strings.TrimSpace(text[start.pos:]) != "" {
+		chunks = append(chunks, renderChunk(start.state, text[start.pos:], s.state))
+	}
+	return chunks
+}
+
+func (c *Client) SendSplitTextMessage(message *Message) ([]string, error) {
+	return c.SendSplitTextMessageWithContext(context.Background(), message)
+}
+
+// SendSplitTextMessageWithContext sends the text message split by SplitText into messages not longer than
+// the limit of the client. It returns IDs of the sent messages, if a message fails, IDs of the sent ones are returned with the error.
+func (c *Client) SendSplitTextMessageWithContext(ctx context.Context, message *Message) ([]string, error) {
+	if message == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+
+	limit := c.maxTextLength
+	if limit == 0 {
+		limit = DefaultMaxTextLength
+	}
+
+	chunks := SplitText(message.Text, message.ParseMode, limit)
+	if len(chunks) == 1 {
+		if err := c.SendTextMessageWithContext(ctx, message); err != nil {
+			return nil, err
+		}
+		return []string{message.ID}, nil
+	}
+	if message.Format != nil {
+		return nil, fmt.Errorf("text with format cannot be split")
+	}
+
+	ids := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		part := &Message{
+			client:      c,
+			ContentType: Text,
+			Chat:        message.Chat,
+			Text:        chunk,
+			ParseMode:   message.ParseMode,
+			RequestID:   message.RequestID,
+			ReplyMsgID:  message.ReplyMsgID,
+		}
+		if i == 0 {
+			part.ForwardMsgID = message.ForwardMsgID
+			part.ForwardChatID = message.ForwardChatID
+		} else {
+			part.ReplyMsgID = ids[0]
+		}
+		if i == len(chunks)-1 {
+			part.InlineKeyboard = message.InlineKeyboard
+		}
+
+		if err := c.SendTextMessageWithContext(ctx, part); err != nil {
+			return ids, fmt.Errorf("cannot send part %d of %d: %w", i+1, len(chunks), err)
+		}
+		ids = append(ids, part.ID)
+	}
+
+	message.ID = ids[0]
+	return ids, nil
+}
@@ -0,0 +1,114 @@
+package botgolang
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertChunksFit(t *testing.T, chunks []string, limit int) {
+	t.Helper()
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, UTF16Len(chunk), limit, chunk)
+	}
+}
+
+func TestSplitText_Plain(t *testing.T) {
+	text := "First paragraph is here.\n\nSecond paragraph\nhas two lines.\n\nThird."
+
+	chunks := SplitText(text, "", 40)
+	assert.Equal(t, []string{"First paragraph is here.", "Second paragraph\nhas two lines.\n\nThird."}, chunks)
+
+	chunks = SplitText(text, "", 30)
+	assert.Equal(t, []string{"First paragraph is here.", "Second paragraph", "has two lines.\n\nThird."}, chunks)
+
+	chunks = SplitText("one two three four five six", "", 10)
+	assert.Equal(t, []string{"one two", "three four", "five six"}, chunks)
+
+	assert.Equal(t, []string{"short"}, SplitText("short", "", 10))
+	assert.Equal(t, []string{"abcde", "fghij", "k"}, SplitText("abcdefghijk", "", 5))
+}
+
+func TestSplitText_HTML(t *testing.T) {
+	text := `<b>bold text that is long</b> and <a href="https://example.com/very/long">link &amp; text</a>` +
+		"\n<pre><code class=\"go\">line one\nline two\nline three</code></pre>"
+
+	chunks := SplitText(text, ParseModeHTML, 60)
+	assertChunksFit(t, chunks, 60)
+	assert.Equal(t, []string{
+		"<b>bold text that is long</b> and",
+		`<a href="https://example.com/very/long">link &amp; text</a>`,
+		"<pre><code class=\"go\">line one\nline two</code></pre>",
+		"<pre><code class=\"go\">line three</code></pre>",
+	}, chunks)
+
+	chunks = SplitText("<i>"+strings.Repeat("word ", 10)+"</i>", ParseModeHTML, 25)
+	assertChunksFit(t, chunks, 25)
+	for _, chunk := range chunks {
+		assert.True(t, strings.HasPrefix(chunk, "<i>") && strings.HasSuffix(chunk, "</i>"), chunk)
+	}
+}
+
+func TestSplitText_MarkdownV2(t *testing.T) {
+	text := "*bold words here* then \\*escaped\\* [a link](https://example.com/a\\)b)\n" +
+		"```python\nprint(1)\nprint(2)\nprint(3)\n```"
+
+	chunks := SplitText(text, ParseModeMarkdownV2, 36)
+	assertChunksFit(t, chunks, 36)
+	assert.Equal(t, []string{
+		"*bold words here* then \\*escaped\\*",
+		"[a link](https://example.com/a\\)b)",
+		"```python\nprint(1)\nprint(2)\n```",
+		"```python\nprint(3)\n```",
+	}, chunks)
+
+	chunks = SplitText("_"+strings.Repeat("it ", 10)+"_", ParseModeMarkdownV2, 12)
+	assertChunksFit(t, chunks, 12)
+	for _, chunk := range chunks {
+		assert.True(t, strings.HasPrefix(chunk, "_") && strings.HasSuffix(chunk, "_"), chunk)
+	}
+}
+
+func TestMessage_SendSplit(t *testing.T) {
+	var mu sync.Mutex
+	var requests []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.URL.Query())
+		_, _ = fmt.Fprintf(w, `{"ok":true,"msgId":"%d"}`, len(requests))
+	}))
+	defer server.Close()
+
+	client := NewCustomClientWithLogger(http.DefaultClient, server.URL, "test_token", nil)
+	client.maxTextLength = 12
+
+	keyboard := NewKeyboard()
+	keyboard.AddRow(NewCallbackButton("ok", "ok"))
+	message := &Message{client: client, Chat: Chat{ID: "chat"}, Text: "first line\nsecond line\nthird", InlineKeyboard: &keyboard}
+
+	ids, err := message.SendSplit()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Equal(t, "1", message.ID)
+
+	require.Len(t, requests, 3)
+	assert.Equal(t, "first line", requests[0].Get("text"))
+	assert.Empty(t, requests[0].Get("replyMsgId"))
+	assert.Equal(t, "1", requests[1].Get("replyMsgId"))
+	assert.Equal(t, "1", requests[2].Get("replyMsgId"))
+	assert.Empty(t, requests[1].Get("inlineKeyboardMarkup"))
+	assert.NotEmpty(t, requests[2].Get("inlineKeyboardMarkup"))
+
+	short := &Message{client: client, Chat: Chat{ID: "chat"}, Text: "short"}
+	ids, err = short.SendSplit()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4"}, ids)
+}
@@ -0,0 +1,135 @@
+package botgolang
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// Attributes of spans started by the library
+const (
+	AttributeEndpoint  = "botgolang.endpoint"
+	AttributeChatID    = "botgolang.chat_id"
+	AttributeRequestID = "botgolang.request_id"
+	AttributeEventID   = "botgolang.event_id"
+	AttributeEventType = "botgolang.event_type"
+)
+
+// Tracer starts spans of API calls and received events.
+// Implement it to bind the library to OpenTelemetry or any other tracing system.
+type Tracer interface {
+	// Start starts a span, the returned context must carry the span,
+	// so spans started with it become its children
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a traced operation
+type Span interface {
+	// SetAttribute attaches the key-value pair to the span
+	SetAttribute(key, value string)
+
+	// RecordError marks the span as failed
+	RecordError(err error)
+
+	// End completes the span
+	End()
+
+	// TraceID returns the identifier of the trace, it is sent to API as request-id of messages
+	TraceID() string
+}
+
+type spanContextKey struct{}
+
+// SpanFromContext returns the span started by the library which is carried by ctx or nil
+func SpanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanContextKey{}).(Span)
+	return span
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, string) {}
+
+func (noopSpan) RecordError(error) {}
+
+func (noopSpan) End() {}
+
+func (noopSpan) TraceID() string {
+	return ""
+}
+
+// startSpan starts a span with the tracer, it returns a no-op span if there is no tracer
+func startSpan(ctx context.Context, tracer Tracer, name string) (context.Context, Span) {
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	ctx, span := tracer.Start(ctx, name)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// traceCall starts a span of API call
+func (c *Client) traceCall(ctx context.Context, call *APICall) (context.Context, Span) {
+	ctx, span := startSpan(ctx, c.tracer, "botgolang "+call.Path)
+	span.SetAttribute(AttributeEndpoint, call.Path)
+	if chatID := call.Params.Get("chatId"); chatID != "" {
+		span.SetAttribute(AttributeChatID, chatID)
+	}
+	if requestID := call.Params.Get("request-id"); requestID != "" {
+		span.SetAttribute(AttributeRequestID, requestID)
+	}
+	return ctx, span
+}
+
+// endCall completes the span of API call with its result
+func endCall(span Span, call *APICall, err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RequestID != "" && call.Params.Get("request-id") == "" {
+		span.SetAttribute(AttributeRequestID, apiErr.RequestID)
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// traceEvent starts a span of received event, handlers get it with Event.Context
+func (c *Client) traceEvent(ctx context.Context, event *Event) (context.Context, Span) {
+	ctx, span := startSpan(ctx, c.tracer, "botgolang event "+string(event.Type))
+	span.SetAttribute(AttributeEventID, strconv.Itoa(event.EventID))
+	span.SetAttribute(AttributeEventType, string(event.Type))
+	if chatID := event.ChatID(); chatID != "" {
+		span.SetAttribute(AttributeChatID, chatID)
+	}
+	return ctx, span
+}
+
+// eventSpan is the span of received event shared by copies of the event, it is ended once
+type eventSpan struct {
+	span Span
+	once sync.Once
+}
+
+// end completes the span, err is recorded if it is not nil
+func (s *eventSpan) end(err error) {
+	if s == nil {
+		return
+	}
+	s.once.Do(func() {
+		if err != nil {
+			s.span.RecordError(err)
+		}
+		s.span.End()
+	})
+}
+
+// messageRequestID fills empty RequestID of the message with the trace of ctx, so it can be found in server logs
+func messageRequestID(ctx context.Context, message *Message) string {
+	if message.RequestID == "" {
+		if span := SpanFromContext(ctx); span != nil {
+			message.RequestID = span.TraceID()
+		}
+	}
+	return message.RequestID
+}
@@ -0,0 +1,209 @@
+package botgolang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSpan struct {
+	name       string
+	parent     *testSpan
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key, value string) {
+	s.attributes[key] = value
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+func (s *testSpan) TraceID() string {
+	if s.parent != nil {
+		return s.parent.TraceID()
+	}
+	return "trace-" + s.name
+}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	span := &testSpan{name: name, attributes: make(map[string]string)}
+	if parent, ok := SpanFromContext(ctx).(*testSpan); ok {
+		span.parent = parent
+	}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestClient_DoWithContext_Tracing(t *testing.T) {
+	var requestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.URL.Query().Get("request-id")
+		if r.URL.Path == "/chats/getInfo" {
+			w.Header().Set("X-Request-Id", "server-request")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"msgId":"1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	tracer := &testTracer{}
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+	client.tracer = tracer
+
+	event := &Event{EventID: 7, Type: NEW_MESSAGE, Payload: EventPayload{BaseEventPayload: BaseEventPayload{Chat: Chat{ID: "chat"}}}}
+	ctx, eventSpan := client.traceEvent(context.Background(), event)
+	event.ctx = ctx
+
+	message := &Message{Chat: Chat{ID: "chat"}, Text: "text"}
+	require.NoError(t, client.SendTextMessageWithContext(event.Context(), message))
+
+	assert.Equal(t, "trace-botgolang event newMessage", message.RequestID)
+	assert.Equal(t, message.RequestID, requestID)
+
+	_, err := client.GetChatInfoWithContext(event.Context(), "chat")
+	require.Error(t, err)
+	eventSpan.End()
+
+	require.Len(t, tracer.spans, 3)
+	assert.Equal(t, map[string]string{
+		AttributeEventID:   "7",
+		AttributeEventType: "newMessage",
+		AttributeChatID:    "chat",
+	}, tracer.spans[0].attributes)
+
+	send := tracer.spans[1]
+	assert.Equal(t, "botgolang /messages/sendText", send.name)
+	assert.Same(t, tracer.spans[0], send.parent)
+	assert.Equal(t, map[string]string{
+		AttributeEndpoint:  "/messages/sendText",
+		AttributeChatID:    "chat",
+		AttributeRequestID: "trace-botgolang event newMessage",
+	}, send.attributes)
+	assert.NoError(t, send.err)
+
+	get := tracer.spans[2]
+	assert.Equal(t, "server-request", get.attributes[AttributeRequestID])
+	assert.Error(t, get.err)
+	for _, span := range tracer.spans {
+		assert.True(t, span.ended)
+	}
+
+	// a message with request id keeps it
+	message = &Message{Chat: Chat{ID: "chat"}, Text: "text", RequestID: "own"}
+	require.NoError(t, client.SendTextMessageWithContext(event.Context(), message))
+	assert.Equal(t, "own", requestID)
+}
+
+func TestClient_TraceEvent_CallbackQuery(t *testing.T) {
+	tracer := &testTracer{}
+	client := &Client{tracer: tracer}
+
+	event := &Event{EventID: 3, Type: CALLBACK_QUERY, Payload: EventPayload{
+		CallbackMsg: BaseEventPayload{Chat: Chat{ID: "chat"}},
+	}}
+	_, span := client.traceEvent(context.Background(), event)
+	span.End()
+
+	require.Len(t, tracer.spans, 1)
+	assert.Equal(t, "chat", tracer.spans[0].attributes[AttributeChatID])
+}
+
+func TestDispatcher_EventSpanLastsUntilHandled(t *testing.T) {
+	tracer := &testTracer{}
+	client := &Client{tracer: tracer}
+	failure := errors.New("failure")
+
+	dispatcher := NewDispatcher(HandlerFunc(func(ctx context.Context, event Event) error {
+		span := SpanFromContext(ctx).(*testSpan)
+		assert.False(t, span.ended)
+
+		_, child := startSpan(ctx, tracer, "handler")
+		child.End()
+
+		if event.EventID == 2 {
+			return failure
+		}
+		return nil
+	}), DispatcherConfig{Workers: 1})
+
+	events := make(chan Event, 2)
+	for i := 1; i <= 2; i++ {
+		event := newDispatcherTestEvent(i, "chat")
+		var span Span
+		event.ctx, span = client.traceEvent(context.Background(), &event)
+		event.span = &eventSpan{span: span}
+		events <- event
+	}
+	close(events)
+	dispatcher.Run(events)
+
+	require.Len(t, tracer.spans, 4)
+	assert.Same(t, tracer.spans[0], tracer.spans[2].parent)
+	assert.Same(t, tracer.spans[1], tracer.spans[3].parent)
+	for _, span := range tracer.spans {
+		assert.True(t, span.ended)
+	}
+	assert.NoError(t, tracer.spans[0].err)
+	assert.ErrorIs(t, tracer.spans[1].err, failure)
+}
+
+func TestUpdater_EventSpan(t *testing.T) {
+	tests := []struct {
+		name    string
+		offsets bool
+		ended   bool
+	}{
+		{name: "ends_on_delivery", ended: true},
+		{name: "lasts_until_ack", offsets: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updater := newUpdatesTestUpdater(t, newUpdatesTestServer(t))
+			tracer := &testTracer{}
+			updater.client.tracer = tracer
+			if tt.offsets {
+				updater.offsets = newOffsetTracker(NewMemoryOffsetStore(), 0)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ch := make(chan Event)
+			go updater.RunUpdatesCheck(ctx, ch)
+
+			first := <-ch
+			// the second event is sent after the updater is done with the first one
+			<-ch
+
+			span := SpanFromContext(first.Context()).(*testSpan)
+			assert.Equal(t, tt.ended, span.ended)
+
+			require.NoError(t, first.Ack())
+			assert.True(t, span.ended)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package botgolang
 
 //go:generate easyjson -all types.go
 
+import "context"
+
 type EventType string
 
 type PartType string
@@ -15,6 +17,9 @@ const (
 	NEW_CHAT_MEMBERS  EventType = "newChatMembers"
 	LEFT_CHAT_MEMBERS EventType = "leftChatMembers"
 	CALLBACK_QUERY    EventType = "callbackQuery"
+	CHANGED_CHAT_INFO EventType = "changedChatInfo"
+	NEW_THREAD        EventType = "newThread"
+	CHANGED_THREAD    EventType = "changedThread"
 
 	STICKER PartType = "sticker"
 	MENTION PartType = "mention"
@@ -22,6 +27,8 @@ const (
 	FILE    PartType = "file"
 	FORWARD PartType = "forward"
 	REPLY   PartType = "reply"
+
+	INLINE_KEYBOARD PartType = "inlineKeyboardMarkup"
 )
 
 type Response struct {
@@ -178,6 +185,9 @@ type PartMessage struct {
 	MsgID     string  `json:"msgId"`
 	Text      string  `json:"text"`
 	Timestamp int     `json:"timestamp"`
+
+	// Chat of the message, it can be empty for replies to messages of the same chat
+	Chat Chat `json:"chat"`
 }
 
 type PartPayload struct {
@@ -188,22 +198,38 @@ type PartPayload struct {
 	Caption     string      `json:"caption"`
 	Type        string      `json:"type"`
 	PartMessage PartMessage `json:"message"`
-	Message     PartMessage `json:"-"`
+
+	// Rows of buttons of the inlineKeyboardMarkup part, its payload is an array
+	InlineKeyboard [][]Button `json:"-"`
 }
 
+// Event is an update received from API.
+// It is decoded by hand to keep the raw payload for typed payloads and unknown events.
+//
+//easyjson:skip
 type Event struct {
-	client *Client
+	client  *Client
+	ctx     context.Context
+	offsets *offsetTracker
+	span    *eventSpan
+	raw     []byte
 
 	// Id of the event
 	EventID int `json:"eventId"`
 
-	// Type of the event: newMessage, editedMessage, deletedMessage, pinnedMessage, unpinnedMessage, newChatMembers
+	// Type of the event, see EventType constants.
+	// Payloads of events without fields in EventPayload are returned by typed accessors,
+	// e.g. ChatInfoChange, or by Unknown for types which the library doesn't know.
 	Type EventType `json:"type"`
 
 	// Payload of the event
 	Payload EventPayload `json:"payload"`
 }
 
+// Part is a part of the message, the payload depends on the type.
+// It is decoded by hand because the payload of inlineKeyboardMarkup parts is not an object.
+//
+//easyjson:skip
 type Part struct {
 	// Type of the part
 	Type PartType `json:"type"`
@@ -219,3 +245,33 @@ func (ep *EventPayload) CallbackQuery() *ButtonResponse {
 		CallbackData: ep.CallbackData,
 	}
 }
+
+// ChatID returns the id of the chat the event belongs to,
+// the chat of callback queries is taken from the message with the button
+func (e *Event) ChatID() string {
+	if e.Payload.Chat.ID != "" {
+		return e.Payload.Chat.ID
+	}
+	return e.Payload.CallbackMsg.Chat.ID
+}
+
+// Context returns the context of the event handling, it carries the span of the event if tracing is enabled.
+// The span of events handled by Bot.Dispatch or received by the updater with an OffsetStore lasts until
+// the event is acknowledged with Ack, otherwise it ends when the event is delivered to the channel.
+func (e *Event) Context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// Ack marks the event as handled and ends its span.
+// If the updater has an OffsetStore, the offset is saved when this event and all events before it are acknowledged,
+// events which are not acknowledged are received again after restart.
+func (e *Event) Ack() error {
+	e.span.end(nil)
+	if e.offsets == nil {
+		return nil
+	}
+	return e.offsets.ack(e.EventID)
+}
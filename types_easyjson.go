@@ -882,6 +882,8 @@ func easyjson6601e8cdDecodeGithubComMailRuImBotGolang10(in *jlexer.Lexer, out *P
 			out.Text = string(in.String())
 		case "timestamp":
 			out.Timestamp = int(in.Int())
+		case "chat":
+			(out.Chat).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -916,6 +918,11 @@ func easyjson6601e8cdEncodeGithubComMailRuImBotGolang10(out *jwriter.Writer, in
 		out.RawString(prefix)
 		out.Int(int(in.Timestamp))
 	}
+	{
+		const prefix string = ",\"chat\":"
+		out.RawString(prefix)
+		(in.Chat).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -942,80 +949,7 @@ func (v *PartMessage) UnmarshalJSON(data []byte) error {
 func (v *PartMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson6601e8cdDecodeGithubComMailRuImBotGolang10(l, v)
 }
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang11(in *jlexer.Lexer, out *Part) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "type":
-			out.Type = PartType(in.String())
-		case "payload":
-			(out.Payload).UnmarshalEasyJSON(in)
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang11(out *jwriter.Writer, in Part) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"type\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Type))
-	}
-	{
-		const prefix string = ",\"payload\":"
-		out.RawString(prefix)
-		(in.Payload).MarshalEasyJSON(out)
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v Part) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang11(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Part) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang11(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *Part) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang11(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Part) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang11(l, v)
-}
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang12(in *jlexer.Lexer, out *MembersListResponse) {
+func easyjson6601e8cdDecodeGithubComMailRuImBotGolang11(in *jlexer.Lexer, out *MembersListResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1067,7 +1001,7 @@ func easyjson6601e8cdDecodeGithubComMailRuImBotGolang12(in *jlexer.Lexer, out *M
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang12(out *jwriter.Writer, in MembersListResponse) {
+func easyjson6601e8cdEncodeGithubComMailRuImBotGolang11(out *jwriter.Writer, in MembersListResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1093,27 +1027,27 @@ func easyjson6601e8cdEncodeGithubComMailRuImBotGolang12(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v MembersListResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang12(&w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang11(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v MembersListResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang12(w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang11(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *MembersListResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang12(&r, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang11(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *MembersListResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang12(l, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang11(l, v)
 }
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang13(in *jlexer.Lexer, out *EventPayload) {
+func easyjson6601e8cdDecodeGithubComMailRuImBotGolang12(in *jlexer.Lexer, out *EventPayload) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1241,7 +1175,7 @@ func easyjson6601e8cdDecodeGithubComMailRuImBotGolang13(in *jlexer.Lexer, out *E
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang13(out *jwriter.Writer, in EventPayload) {
+func easyjson6601e8cdEncodeGithubComMailRuImBotGolang12(out *jwriter.Writer, in EventPayload) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1358,107 +1292,27 @@ func easyjson6601e8cdEncodeGithubComMailRuImBotGolang13(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v EventPayload) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang13(&w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang12(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v EventPayload) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang13(w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang12(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *EventPayload) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang13(&r, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang12(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *EventPayload) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang13(l, v)
-}
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang14(in *jlexer.Lexer, out *Event) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "eventId":
-			out.EventID = int(in.Int())
-		case "type":
-			out.Type = EventType(in.String())
-		case "payload":
-			(out.Payload).UnmarshalEasyJSON(in)
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang14(out *jwriter.Writer, in Event) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"eventId\":"
-		out.RawString(prefix[1:])
-		out.Int(int(in.EventID))
-	}
-	{
-		const prefix string = ",\"type\":"
-		out.RawString(prefix)
-		out.String(string(in.Type))
-	}
-	{
-		const prefix string = ",\"payload\":"
-		out.RawString(prefix)
-		(in.Payload).MarshalEasyJSON(out)
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v Event) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang14(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Event) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang14(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *Event) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang14(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Event) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang14(l, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang12(l, v)
 }
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang15(in *jlexer.Lexer, out *Contact) {
+func easyjson6601e8cdDecodeGithubComMailRuImBotGolang13(in *jlexer.Lexer, out *Contact) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1493,7 +1347,7 @@ func easyjson6601e8cdDecodeGithubComMailRuImBotGolang15(in *jlexer.Lexer, out *C
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang15(out *jwriter.Writer, in Contact) {
+func easyjson6601e8cdEncodeGithubComMailRuImBotGolang13(out *jwriter.Writer, in Contact) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1518,27 +1372,27 @@ func easyjson6601e8cdEncodeGithubComMailRuImBotGolang15(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Contact) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang15(&w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang13(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Contact) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang15(w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang13(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Contact) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang15(&r, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang13(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Contact) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang15(l, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang13(l, v)
 }
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang16(in *jlexer.Lexer, out *ChatMember) {
+func easyjson6601e8cdDecodeGithubComMailRuImBotGolang14(in *jlexer.Lexer, out *ChatMember) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1573,7 +1427,7 @@ func easyjson6601e8cdDecodeGithubComMailRuImBotGolang16(in *jlexer.Lexer, out *C
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang16(out *jwriter.Writer, in ChatMember) {
+func easyjson6601e8cdEncodeGithubComMailRuImBotGolang14(out *jwriter.Writer, in ChatMember) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1598,27 +1452,27 @@ func easyjson6601e8cdEncodeGithubComMailRuImBotGolang16(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v ChatMember) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang16(&w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang14(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ChatMember) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang16(w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang14(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ChatMember) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang16(&r, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang14(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ChatMember) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang16(l, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang14(l, v)
 }
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang17(in *jlexer.Lexer, out *BotInfo) {
+func easyjson6601e8cdDecodeGithubComMailRuImBotGolang15(in *jlexer.Lexer, out *BotInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1678,7 +1532,7 @@ func easyjson6601e8cdDecodeGithubComMailRuImBotGolang17(in *jlexer.Lexer, out *B
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang17(out *jwriter.Writer, in BotInfo) {
+func easyjson6601e8cdEncodeGithubComMailRuImBotGolang15(out *jwriter.Writer, in BotInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1724,27 +1578,27 @@ func easyjson6601e8cdEncodeGithubComMailRuImBotGolang17(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v BotInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang17(&w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v BotInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang17(w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *BotInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang17(&r, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *BotInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang17(l, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang15(l, v)
 }
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang18(in *jlexer.Lexer, out *BaseEventPayload) {
+func easyjson6601e8cdDecodeGithubComMailRuImBotGolang16(in *jlexer.Lexer, out *BaseEventPayload) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1793,7 +1647,7 @@ func easyjson6601e8cdDecodeGithubComMailRuImBotGolang18(in *jlexer.Lexer, out *B
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang18(out *jwriter.Writer, in BaseEventPayload) {
+func easyjson6601e8cdEncodeGithubComMailRuImBotGolang16(out *jwriter.Writer, in BaseEventPayload) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1837,27 +1691,27 @@ func easyjson6601e8cdEncodeGithubComMailRuImBotGolang18(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v BaseEventPayload) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang18(&w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang16(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v BaseEventPayload) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang18(w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang16(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *BaseEventPayload) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang18(&r, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang16(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *BaseEventPayload) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang18(l, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang16(l, v)
 }
-func easyjson6601e8cdDecodeGithubComMailRuImBotGolang19(in *jlexer.Lexer, out *AdminsListResponse) {
+func easyjson6601e8cdDecodeGithubComMailRuImBotGolang17(in *jlexer.Lexer, out *AdminsListResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1909,7 +1763,7 @@ func easyjson6601e8cdDecodeGithubComMailRuImBotGolang19(in *jlexer.Lexer, out *A
 		in.Consumed()
 	}
 }
-func easyjson6601e8cdEncodeGithubComMailRuImBotGolang19(out *jwriter.Writer, in AdminsListResponse) {
+func easyjson6601e8cdEncodeGithubComMailRuImBotGolang17(out *jwriter.Writer, in AdminsListResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1935,23 +1789,23 @@ func easyjson6601e8cdEncodeGithubComMailRuImBotGolang19(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v AdminsListResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang19(&w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AdminsListResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6601e8cdEncodeGithubComMailRuImBotGolang19(w, v)
+	easyjson6601e8cdEncodeGithubComMailRuImBotGolang17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AdminsListResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang19(&r, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AdminsListResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6601e8cdDecodeGithubComMailRuImBotGolang19(l, v)
+	easyjson6601e8cdDecodeGithubComMailRuImBotGolang17(l, v)
 }
@@ -3,6 +3,7 @@ package botgolang
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	dura "github.com/hako/durafmt"
@@ -18,10 +19,21 @@ var (
 )
 
 type Updater struct {
-	logger      *logrus.Logger
+	logger      Logger
 	client      *Client
 	lastEventID int
 	PollTime    int
+	offsets     *offsetTracker
+	started     bool
+
+	// dispatched is set by Bot.Dispatch, which acknowledges every event after handling
+	dispatched bool
+
+	mu  sync.Mutex
+	run *updaterRun
+
+	// stopped is set by Shutdown called before RunUpdatesCheck has started, so the run doesn't start
+	stopped bool
 }
 
 // NewMessageFromPart returns new message based on part message
@@ -35,39 +47,232 @@ func (u *Updater) NewMessageFromPayload(message EventPayload) *Message {
 	}
 }
 
+// RunUpdatesCheck fills the channel with events until the context is done or Shutdown is called,
+// the channel is closed after that. If Shutdown was called before, the channel is closed at once.
+// Events received before the start are skipped unless the updater continues from a stored offset.
 func (u *Updater) RunUpdatesCheck(ctx context.Context, ch chan<- Event) {
-	_, err := u.GetLastEventsWithContext(ctx, 0)
-	if err != nil {
-		u.logger.WithFields(logrus.Fields{
-			"err": err,
-		}).Debug("cannot make initial request to events")
+	run := u.startRun()
+	if run == nil {
+		close(ch)
+		return
 	}
+	defer u.finishRun(run, ch)
 
-	for {
+	pollCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
 		select {
-		case <-ctx.Done():
-			close(ch)
-			return
-		default:
-			events, err := u.GetLastEventsWithContext(ctx, u.PollTime)
-			if err != nil {
-				u.logger.WithFields(logrus.Fields{
-					"err":            err,
-					"retry interval": sleepTimeStr,
-				}).Errorf("Failed to get updates, retrying in %s ...", sleepTimeStr)
-				time.Sleep(sleepTime)
-
-				continue
+		case <-run.stop:
+			cancel()
+		case <-pollCtx.Done():
+		}
+	}()
+
+	if !u.started {
+		_, err := u.GetLastEventsWithContext(pollCtx, 0)
+		if err != nil {
+			u.logger.Log(LogLevelDebug, "cannot make initial request to events", LogFields{
+				"err": err,
+			})
+		}
+		u.started = true
+	}
+
+	for pollCtx.Err() == nil {
+		previous := u.lastEventID
+		events, err := u.GetLastEventsWithContext(pollCtx, u.PollTime)
+		if err != nil {
+			if pollCtx.Err() != nil {
+				return
+			}
+
+			u.logger.Log(LogLevelError, fmt.Sprintf("Failed to get updates, retrying in %s ...", sleepTimeStr), LogFields{
+				"err":            err,
+				"retry interval": sleepTimeStr,
+			})
+			u.observeError()
+			if err := sleepContext(pollCtx, sleepTime); err != nil {
+				return
 			}
 
-			for _, event := range events {
-				event.client = u.client
-				event.Payload.client = u.client
+			continue
+		}
+
+		if !u.deliver(ctx, run, ch, previous, events) {
+			return
+		}
+	}
+}
+
+// deliver sends the events to the channel, it returns false if the rest of events cannot be delivered.
+// previous is the id of the last event before these ones.
+func (u *Updater) deliver(ctx context.Context, run *updaterRun, ch chan<- Event, previous int, events []*Event) bool {
+	defer u.setPending(0)
+
+	for i, event := range events {
+		event.client = u.client
+		event.Payload.client = u.client
+		if u.offsets != nil {
+			u.offsets.track(event.EventID)
+			event.offsets = u.offsets
+		}
+		u.observeEvent(event, len(events)-i)
+
+		// handlers outlive the run, e.g. when received events are handled during shutdown
+		var span Span
+		event.ctx, span = u.client.traceEvent(detachedContext{ctx}, event)
+		event.span = &eventSpan{span: span}
 
-				ch <- *event
+		sent := time.Now()
+		select {
+		case ch <- *event:
+			u.observeDelivery(sent)
+			previous = event.EventID
+			// nobody is obliged to acknowledge the event, so its span ends on delivery
+			if u.offsets == nil && !u.dispatched {
+				event.span.end(nil)
 			}
+		case <-ctx.Done():
+			event.span.end(nil)
+			u.lose(run, previous, events[i:])
+			return false
+		case <-run.abort:
+			event.span.end(nil)
+			u.lose(run, previous, events[i:])
+			return false
 		}
 	}
+
+	return true
+}
+
+// detachedContext keeps the values of its parent, but it is never cancelled
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// lose records undelivered events and rewinds the updater to receive them again
+func (u *Updater) lose(run *updaterRun, previous int, events []*Event) {
+	if u.offsets != nil {
+		u.offsets.untrack(events[0].EventID)
+	}
+	u.lastEventID = previous
+
+	for _, event := range events {
+		run.undelivered = append(run.undelivered, *event)
+	}
+
+	u.logger.Log(LogLevelWarn, "events are not delivered", LogFields{
+		"count":    len(events),
+		"event_id": events[0].EventID,
+	})
+}
+
+// Shutdown stops polling for events and waits until the already received events are delivered to the channel.
+// If the context is done first, the rest of events is returned in the report.
+// They are received again by the next RunUpdatesCheck or after restart if the updater has an OffsetStore.
+// If RunUpdatesCheck is not running yet, its next call returns at once.
+func (u *Updater) Shutdown(ctx context.Context) (*ShutdownReport, error) {
+	u.mu.Lock()
+	run := u.run
+	if run == nil {
+		u.stopped = true
+	}
+	u.mu.Unlock()
+
+	report := &ShutdownReport{}
+	if run == nil {
+		return report, nil
+	}
+
+	run.stopOnce.Do(func() {
+		close(run.stop)
+	})
+
+	var err error
+	select {
+	case <-run.done:
+	case <-ctx.Done():
+		run.abortOnce.Do(func() {
+			close(run.abort)
+		})
+		<-run.done
+		err = ctx.Err()
+	}
+
+	report.Undelivered = run.undelivered
+	return report, err
+}
+
+// updaterRun is the state of one RunUpdatesCheck call
+type updaterRun struct {
+	stop      chan struct{}
+	stopOnce  sync.Once
+	abort     chan struct{}
+	abortOnce sync.Once
+	done      chan struct{}
+
+	undelivered []Event
+}
+
+// startRun registers the run, it returns nil if Shutdown was called before and resets the stopped flag
+func (u *Updater) startRun() *updaterRun {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.stopped {
+		u.stopped = false
+		return nil
+	}
+
+	u.run = &updaterRun{
+		stop:  make(chan struct{}),
+		abort: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	return u.run
+}
+
+func (u *Updater) finishRun(run *updaterRun, ch chan<- Event) {
+	close(ch)
+
+	u.mu.Lock()
+	if u.run == run {
+		u.run = nil
+	}
+	u.mu.Unlock()
+
+	close(run.done)
+}
+
+// ShutdownReport describes events lost on shutdown.
+// Lost events are not acknowledged, so with an OffsetStore they are received again after restart.
+type ShutdownReport struct {
+	// Events received from API but not delivered to the updates channel
+	Undelivered []Event
+
+	// Events delivered to the dispatcher but not handled
+	Unhandled []Event
+
+	// Number of handlers still running when the deadline was reached
+	Running int
+}
+
+// Lost returns the number of events which were not handled
+func (r *ShutdownReport) Lost() int {
+	return len(r.Undelivered) + len(r.Unhandled)
 }
 
 func (u *Updater) GetLastEvents(pollTime int) ([]*Event, error) {
@@ -77,11 +282,11 @@ func (u *Updater) GetLastEvents(pollTime int) ([]*Event, error) {
 func (u *Updater) GetLastEventsWithContext(ctx context.Context, pollTime int) ([]*Event, error) {
 	events, err := u.client.GetEventsWithContext(ctx, u.lastEventID, pollTime)
 	if err != nil {
-		u.logger.WithFields(logrus.Fields{
+		u.logger.Log(LogLevelDebug, "events getting error", LogFields{
 			"err":    err,
 			"events": events,
-		}).Debug("events getting error")
-		return events, fmt.Errorf("cannot get events: %s", err)
+		})
+		return events, fmt.Errorf("cannot get events: %w", err)
 	}
 
 	count := len(events)
@@ -92,7 +297,32 @@ func (u *Updater) GetLastEventsWithContext(ctx context.Context, pollTime int) ([
 	return events, nil
 }
 
+// NewUpdater returns new updater, it writes logs to the logrus logger.
+// Use NewUpdaterWithLogger for other loggers.
 func NewUpdater(client *Client, pollTime int, logger *logrus.Logger) *Updater {
+	return NewUpdaterWithLogger(client, pollTime, NewLogrusLogger(logger))
+}
+
+// NewUpdaterFromStore returns new updater which continues from the offset in the store.
+// The offset is saved when events are acknowledged with Event.Ack.
+func NewUpdaterFromStore(client *Client, pollTime int, logger Logger, store OffsetStore) (*Updater, error) {
+	offset, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load offset: %w", err)
+	}
+
+	updater := NewUpdaterWithLogger(client, pollTime, logger)
+	updater.lastEventID = offset
+	updater.started = offset > 0
+	updater.offsets = newOffsetTracker(store, offset)
+	return updater, nil
+}
+
+// NewUpdaterWithLogger returns new updater writing logs to the logger
+func NewUpdaterWithLogger(client *Client, pollTime int, logger Logger) *Updater {
+	if logger == nil {
+		logger = noopLogger{}
+	}
 	if pollTime == 0 {
 		pollTime = 60
 	}
@@ -104,3 +334,45 @@ func NewUpdater(client *Client, pollTime int, logger *logrus.Logger) *Updater {
 		logger:      logger,
 	}
 }
+
+func (u *Updater) metrics() Metrics {
+	if u.client == nil {
+		return nil
+	}
+	return u.client.metrics
+}
+
+func (u *Updater) observeError() {
+	if m := u.metrics(); m != nil {
+		m.AddCounter(MetricUpdaterErrors, 1, nil)
+	}
+}
+
+// observeEvent records the received event, pending is the number of events not delivered yet including this one
+func (u *Updater) observeEvent(event *Event, pending int) {
+	m := u.metrics()
+	if m == nil {
+		return
+	}
+
+	m.AddCounter(MetricUpdaterEvents, 1, map[string]string{
+		"type": string(event.Type),
+	})
+	m.SetGauge(MetricUpdaterPendingEvents, float64(pending), nil)
+	if event.Payload.Timestamp > 0 {
+		lag := time.Since(time.Unix(int64(event.Payload.Timestamp), 0))
+		m.SetGauge(MetricUpdaterLag, lag.Seconds(), nil)
+	}
+}
+
+func (u *Updater) observeDelivery(sent time.Time) {
+	if m := u.metrics(); m != nil {
+		m.ObserveHistogram(MetricUpdaterChannelWait, time.Since(sent).Seconds(), nil)
+	}
+}
+
+func (u *Updater) setPending(pending int) {
+	if m := u.metrics(); m != nil {
+		m.SetGauge(MetricUpdaterPendingEvents, float64(pending), nil)
+	}
+}
@@ -0,0 +1,268 @@
+package botgolang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUpdatesTestServer returns three events after the initial request and then blocks in long polling
+func newUpdatesTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("pollTime") == "0":
+			_, _ = w.Write([]byte(`{"ok":true,"events":[]}`))
+		case query.Get("lastEventId") == "0":
+			_, _ = w.Write([]byte(`{"ok":true,"events":[
+				{"eventId":1,"type":"newMessage","payload":{"msgId":"1","chat":{"chatId":"chat"}}},
+				{"eventId":2,"type":"newMessage","payload":{"msgId":"2","chat":{"chatId":"chat"}}},
+				{"eventId":3,"type":"newMessage","payload":{"msgId":"3","chat":{"chatId":"chat"}}}
+			]}`))
+		default:
+			<-r.Context().Done()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newUpdatesTestUpdater(t *testing.T, server *httptest.Server) *Updater {
+	t.Helper()
+
+	client := NewCustomClientWithLogger(http.DefaultClient, server.URL, "test_token", nil)
+	return NewUpdaterWithLogger(client, 60, nil)
+}
+
+func TestUpdater_Shutdown_DeliversReceivedEvents(t *testing.T) {
+	updater := newUpdatesTestUpdater(t, newUpdatesTestServer(t))
+
+	ch := make(chan Event)
+	go updater.RunUpdatesCheck(context.Background(), ch)
+
+	first := <-ch
+	assert.Equal(t, 1, first.EventID)
+
+	type result struct {
+		report *ShutdownReport
+		err    error
+	}
+	shutdown := make(chan result)
+	go func() {
+		report, err := updater.Shutdown(context.Background())
+		shutdown <- result{report, err}
+	}()
+
+	var ids []int
+	for event := range ch {
+		ids = append(ids, event.EventID)
+	}
+	assert.Equal(t, []int{2, 3}, ids)
+
+	res := <-shutdown
+	require.NoError(t, res.err)
+	assert.Equal(t, 0, res.report.Lost())
+}
+
+func TestUpdater_Shutdown_Deadline(t *testing.T) {
+	updater := newUpdatesTestUpdater(t, newUpdatesTestServer(t))
+
+	ch := make(chan Event)
+	go updater.RunUpdatesCheck(context.Background(), ch)
+
+	first := <-ch
+	assert.Equal(t, 1, first.EventID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	report, err := updater.Shutdown(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, report.Undelivered, 2)
+	assert.Equal(t, 2, report.Undelivered[0].EventID)
+	assert.Equal(t, 3, report.Undelivered[1].EventID)
+
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// undelivered events are received by the next run
+	assert.Equal(t, 1, updater.lastEventID)
+}
+
+func TestUpdater_Shutdown_BeforeRun(t *testing.T) {
+	updater := newUpdatesTestUpdater(t, newUpdatesTestServer(t))
+
+	report, err := updater.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Lost())
+
+	ch := make(chan Event)
+	done := make(chan struct{})
+	go func() {
+		updater.RunUpdatesCheck(context.Background(), ch)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("updater is not stopped")
+	}
+	_, ok := <-ch
+	assert.False(t, ok)
+}
+
+func TestUpdater_RunUpdatesCheck_Cancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "while_delivering",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"ok":true,"events":[{"eventId":1,"type":"newMessage","payload":{}}]}`))
+			},
+		},
+		{
+			name: "while_sleeping_after_error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			t.Cleanup(server.Close)
+			updater := newUpdatesTestUpdater(t, server)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			ch := make(chan Event)
+			done := make(chan struct{})
+			go func() {
+				updater.RunUpdatesCheck(ctx, ch)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("updater is not stopped")
+			}
+		})
+	}
+}
+
+func TestBot_Shutdown(t *testing.T) {
+	server := newUpdatesTestServer(t)
+	updater := newUpdatesTestUpdater(t, server)
+	bot := &Bot{client: updater.client, updater: updater, logger: noopLogger{}}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	go bot.Dispatch(context.Background(), HandlerFunc(func(ctx context.Context, event Event) error {
+		if event.EventID == 1 {
+			close(started)
+			<-release
+		}
+		return nil
+	}), DispatcherConfig{Workers: 1})
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	report, err := bot.Shutdown(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, report.Undelivered)
+	require.Len(t, report.Unhandled, 2)
+	assert.Equal(t, 2, report.Unhandled[0].EventID)
+	assert.Equal(t, 1, report.Running)
+	assert.Equal(t, 2, report.Lost())
+}
+
+func TestBot_Shutdown_RightAfterDispatch(t *testing.T) {
+	server := newUpdatesTestServer(t)
+	updater := newUpdatesTestUpdater(t, server)
+	bot := &Bot{client: updater.client, updater: updater, logger: noopLogger{}}
+
+	done := make(chan struct{})
+	go func() {
+		bot.Dispatch(context.Background(), HandlerFunc(func(ctx context.Context, event Event) error {
+			return nil
+		}), DispatcherConfig{Workers: 2})
+		close(done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := bot.Shutdown(ctx)
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatch is not stopped")
+	}
+}
+
+func TestBot_Dispatch_HandlerContext(t *testing.T) {
+	server := newUpdatesTestServer(t)
+	updater := newUpdatesTestUpdater(t, server)
+	bot := &Bot{client: updater.client, updater: updater, logger: noopLogger{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	stopped := make(chan error, 1)
+	done := make(chan struct{})
+	cancelled := make(chan struct{})
+
+	go func() {
+		bot.Dispatch(ctx, HandlerFunc(func(ctx context.Context, event Event) error {
+			if event.EventID != 1 {
+				return nil
+			}
+			close(started)
+			<-done
+			// the handler is cancelled by the shutdown deadline only
+			stopped <- ctx.Err()
+			<-ctx.Done()
+			close(cancelled)
+			return nil
+		}), DispatcherConfig{Workers: 1})
+	}()
+
+	<-started
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	close(done)
+	assert.NoError(t, <-stopped)
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer shutdownCancel()
+
+	report, err := bot.Shutdown(shutdownCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, report.Running)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("handler context is not cancelled")
+	}
+}
@@ -0,0 +1,178 @@
+package botgolang
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+)
+
+// UploadProgressFunc is called while a file is being uploaded.
+// sent is the number of bytes of the file sent so far,
+// total is the size of the file or -1 if the size is unknown.
+type UploadProgressFunc func(name string, sent, total int64)
+
+type uploadProgressKey struct{}
+
+// WithUploadProgress returns a context which makes uploads report their progress to fn.
+// It overrides the callback set with BotUploadProgress option.
+func WithUploadProgress(ctx context.Context, fn UploadProgressFunc) context.Context {
+	return context.WithValue(ctx, uploadProgressKey{}, fn)
+}
+
+func (c *Client) uploadProgress(ctx context.Context) UploadProgressFunc {
+	if fn, ok := ctx.Value(uploadProgressKey{}).(UploadProgressFunc); ok {
+		return fn
+	}
+	return c.progress
+}
+
+// uploadSize returns the number of bytes left in the file or -1 if it cannot be determined
+func uploadSize(file UploadFile) int64 {
+	source := uploadSource(file)
+
+	if sized, ok := source.(interface{ Len() int }); ok {
+		return int64(sized.Len())
+	}
+
+	if stater, ok := source.(interface{ Stat() (os.FileInfo, error) }); ok {
+		info, err := stater.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+
+		offset := int64(0)
+		if seeker, ok := source.(io.Seeker); ok {
+			if offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+				return -1
+			}
+		}
+		return info.Size() - offset
+	}
+
+	if seeker, ok := source.(io.Seeker); ok {
+		current, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - current
+	}
+
+	return -1
+}
+
+// multipartUpload streams the file as multipart/form-data body through a pipe,
+// so the file is never buffered in memory as a whole
+type multipartUpload struct {
+	body          io.ReadCloser
+	contentType   string
+	contentLength int64
+
+	// done is closed when the writer stops reading the file
+	done chan struct{}
+}
+
+// finish stops the writer and waits until it stops reading the file, so the file can be rewound
+func (u *multipartUpload) finish() {
+	_ = u.body.Close()
+	<-u.done
+}
+
+func (c *Client) newMultipartUpload(ctx context.Context, file UploadFile) (*multipartUpload, error) {
+	size := uploadSize(file)
+	if c.maxUploadSize > 0 && size > c.maxUploadSize {
+		return nil, fmt.Errorf("file %s has size %d bytes, limit is %d bytes: %w",
+			file.Name(), size, c.maxUploadSize, ErrFileTooLarge)
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	multipartWriter := multipart.NewWriter(pipeWriter)
+
+	upload := &multipartUpload{
+		body:          pipeReader,
+		contentType:   multipartWriter.FormDataContentType(),
+		contentLength: -1,
+		done:          make(chan struct{}),
+	}
+
+	if size >= 0 {
+		overhead, err := multipartOverhead(multipartWriter.Boundary(), file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("cannot calculate content length: %w", err)
+		}
+		upload.contentLength = overhead + size
+	}
+
+	reader := &uploadProgressReader{
+		reader:   file,
+		name:     file.Name(),
+		total:    size,
+		limit:    c.maxUploadSize,
+		progress: c.uploadProgress(ctx),
+	}
+
+	go func() {
+		defer close(upload.done)
+
+		fileWriter, err := multipartWriter.CreateFormFile("file", file.Name())
+		if err == nil {
+			_, err = io.Copy(fileWriter, reader)
+		}
+		if err == nil {
+			err = multipartWriter.Close()
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	return upload, nil
+}
+
+// multipartOverhead returns the size of multipart headers and trailers around the file
+func multipartOverhead(boundary, name string) (int64, error) {
+	buffer := &bytes.Buffer{}
+	multipartWriter := multipart.NewWriter(buffer)
+	if err := multipartWriter.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+	if _, err := multipartWriter.CreateFormFile("file", name); err != nil {
+		return 0, err
+	}
+	if err := multipartWriter.Close(); err != nil {
+		return 0, err
+	}
+	return int64(buffer.Len()), nil
+}
+
+// uploadProgressReader counts bytes read from the file, reports progress and enforces the size limit
+type uploadProgressReader struct {
+	reader   io.Reader
+	name     string
+	sent     int64
+	total    int64
+	limit    int64
+	progress UploadProgressFunc
+}
+
+func (r *uploadProgressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.sent += int64(n)
+
+	if r.limit > 0 && r.sent > r.limit {
+		return n, fmt.Errorf("file %s exceeds limit of %d bytes: %w", r.name, r.limit, ErrFileTooLarge)
+	}
+
+	if r.progress != nil && n > 0 {
+		r.progress(r.name, r.sent, r.total)
+	}
+
+	return n, err
+}
@@ -0,0 +1,158 @@
+package botgolang
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUploadTestServer(t *testing.T, contentLength *int64, calls *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		atomic.StoreInt64(contentLength, r.ContentLength)
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = io.Copy(io.Discard, file)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_Do_UploadContentLength(t *testing.T) {
+	tmpFile, err := os.Create(filepath.Join(t.TempDir(), "file.txt"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, tmpFile.Close())
+	})
+	_, err = tmpFile.WriteString("file content")
+	require.NoError(t, err)
+	_, err = tmpFile.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		file     UploadFile
+		chunked  bool
+		fileSize int64
+	}{
+		{
+			name:     "strings_reader",
+			file:     NewUploadFileFromReader("test.txt", strings.NewReader("test file content")),
+			fileSize: 17,
+		},
+		{
+			name:     "os_file",
+			file:     tmpFile,
+			fileSize: 12,
+		},
+		{
+			name:    "unknown_size",
+			file:    NewUploadFileFromReader("test.txt", io.LimitReader(strings.NewReader("content"), 100)),
+			chunked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contentLength int64
+			var calls int32
+			server := newUploadTestServer(t, &contentLength, &calls)
+			client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+
+			_, err := client.Do("/messages/sendFile", url.Values{}, tt.file)
+
+			require.NoError(t, err)
+			if tt.chunked {
+				assert.EqualValues(t, -1, contentLength)
+			} else {
+				assert.Greater(t, contentLength, tt.fileSize)
+			}
+		})
+	}
+}
+
+func TestClient_Do_UploadProgress(t *testing.T) {
+	var contentLength int64
+	var calls int32
+	server := newUploadTestServer(t, &contentLength, &calls)
+	client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+
+	var lastSent, lastTotal int64
+	client.progress = func(name string, sent, total int64) {
+		assert.Equal(t, "test.txt", name)
+		lastSent, lastTotal = sent, total
+	}
+
+	file := NewUploadFileFromReader("test.txt", strings.NewReader("test file content"))
+	_, err := client.Do("/messages/sendFile", url.Values{}, file)
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 17, lastSent)
+	assert.EqualValues(t, 17, lastTotal)
+
+	var ctxSent int64
+	ctx := WithUploadProgress(context.Background(), func(name string, sent, total int64) {
+		ctxSent = sent
+	})
+	file = NewUploadFileFromReader("test.txt", strings.NewReader("content"))
+	_, err = client.DoWithContext(ctx, "/messages/sendFile", url.Values{}, file)
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, ctxSent)
+}
+
+func TestClient_Do_UploadMaxSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          UploadFile
+		expectedCalls int32
+	}{
+		{
+			name:          "known_size_is_checked_up_front",
+			file:          NewUploadFileFromReader("test.txt", strings.NewReader("test file content")),
+			expectedCalls: 0,
+		},
+		{
+			name:          "unknown_size_is_checked_while_streaming",
+			file:          NewUploadFileFromReader("test.txt", io.LimitReader(strings.NewReader("test file content"), 100)),
+			expectedCalls: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contentLength int64
+			var calls int32
+			server := newUploadTestServer(t, &contentLength, &calls)
+			client := NewCustomClient(http.DefaultClient, server.URL, "test_token", &logrus.Logger{})
+			client.maxUploadSize = 10
+			client.retryPolicy = testRetryPolicy()
+
+			_, err := client.Do("/messages/sendFile", url.Values{}, tt.file)
+
+			require.ErrorIs(t, err, ErrFileTooLarge)
+			if tt.expectedCalls >= 0 {
+				assert.Equal(t, tt.expectedCalls, atomic.LoadInt32(&calls))
+			}
+		})
+	}
+}